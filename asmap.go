@@ -0,0 +1,64 @@
+// asmap.go
+
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AsMap devuelve la configuración como un mapa anidado con las mismas claves que el
+// YAML (las de los tags `mapstructure`), útil para plantillas.
+//
+// Si includeSecrets es false, los campos marcados con `sensitive:"true"` se omiten por
+// completo (no se enmascaran), para que no acaben en manifiestos que no son secretos.
+// El mapa es una copia: modificarlo no afecta a la configuración.
+func (c *Config) AsMap(includeSecrets bool) map[string]any {
+	return structToMap(reflect.ValueOf(c).Elem(), includeSecrets)
+}
+
+// structToMap convierte un struct de configuración en un mapa indexado por sus claves.
+func structToMap(v reflect.Value, includeSecrets bool) map[string]any {
+	t := v.Type()
+	out := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || fieldKey(field) == "-" {
+			continue
+		}
+		if isSensitive(field) && !includeSecrets {
+			continue
+		}
+		out[fieldKey(field)] = plainValue(v.Field(i), includeSecrets)
+	}
+	return out
+}
+
+// plainValue copia un valor convirtiendo structs, mapas y slices en map[string]any y []any.
+func plainValue(v reflect.Value, includeSecrets bool) any {
+	switch v.Kind() {
+	case reflect.Struct:
+		return structToMap(v, includeSecrets)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = plainValue(iter.Value(), includeSecrets)
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = plainValue(v.Index(i), includeSecrets)
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,54 @@
+// asmap_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfigWithSecrets() *Config {
+	return &Config{
+		App:   AppConfig{Name: "filingo"},
+		DB:    DBConfig{Host: "db-host", Password: "db-pass", MaxConnLifeTime: time.Hour},
+		Redis: RedisConfig{Address: "localhost:6379", Password: "redis-pass"},
+		OAuth2: OAuthConfig{
+			GoogleClientID:     "client-id",
+			GoogleClientSecret: "client-secret",
+			SessionSecret:      "session-secret",
+		},
+	}
+}
+
+func TestAsMap_IncludeSecrets(t *testing.T) {
+	m := testConfigWithSecrets().AsMap(true)
+
+	db, ok := m["database"].(map[string]any)
+	require.True(t, ok, "database debería ser un mapa anidado")
+	assert.Equal(t, "db-host", db["host"])
+	assert.Equal(t, "db-pass", db["password"])
+	assert.Equal(t, time.Hour, db["max_connection_life_time"])
+
+	oauth := m["google_oauth2"].(map[string]any)
+	assert.Equal(t, "client-secret", oauth["client_secret"])
+}
+
+func TestAsMap_ExcludeSecrets(t *testing.T) {
+	m := testConfigWithSecrets().AsMap(false)
+
+	// Los secretos se omiten por completo, no se enmascaran.
+	db := m["database"].(map[string]any)
+	assert.Equal(t, "db-host", db["host"])
+	assert.NotContains(t, db, "password")
+
+	redis := m["redis"].(map[string]any)
+	assert.Equal(t, "localhost:6379", redis["address"])
+	assert.NotContains(t, redis, "password")
+
+	oauth := m["google_oauth2"].(map[string]any)
+	assert.Equal(t, "client-id", oauth["client_id"])
+	assert.NotContains(t, oauth, "client_secret")
+	assert.NotContains(t, oauth, "session_secret")
+}
@@ -5,13 +5,24 @@
 package configloader
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 /*
@@ -28,30 +39,105 @@ En viper.Unmarshal(&cfg), Viper ve el campo MaxConns,
 var (
 	// instance contendrá la única instancia de la configuración cargada.
 	instance *Config
-	// once asegura que la configuración se cargue una sola vez.
-	once sync.Once
+	// once asegura que la configuración se cargue una sola vez. Es un puntero para
+	// que Reset pueda sustituirlo bajo mu sin carreras con un Init en curso.
+	once = new(sync.Once)
+	// mu protege instance, initOpts y once cuando Init recarga la configuración.
+	mu sync.RWMutex
+	// initOpts guarda las opciones con las que se cargó la instancia actual.
+	initOpts *Options
+	// initDone se cierra (y se reemplaza por uno nuevo) cada vez que se publica una
+	// instancia, para despertar a quienes esperan en WaitForInit.
+	initDone = make(chan struct{})
+)
+
+// Reset descarta la configuración del singleton para que la siguiente llamada a Init
+// vuelva a cargarla desde cero, con las opciones que sea. Pensada solo para tests
+// (incluidos los de otros paquetes); en producción basta con Options.AllowReinit.
+// Es seguro llamarla de forma concurrente; quienes esperan en WaitForInit siguen
+// esperando hasta el próximo Init. También detiene la vigilancia de Options.Watch y
+// olvida los callbacks registrados con OnChange.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	instance = nil
+	initOpts = nil
+	once = new(sync.Once)
+	if activeWatcher != nil {
+		activeWatcher.close()
+		activeWatcher = nil
+	}
+
+	changeMu.Lock()
+	defer changeMu.Unlock()
+	changeCallbacks = nil
+}
+
+// ErrAlreadyInitialized se devuelve cuando Init se llama de nuevo con opciones
+// distintas a las de la inicialización previa y Options.AllowReinit es false.
+var ErrAlreadyInitialized = errors.New("configloader: la configuración ya fue inicializada con otras opciones")
+
+// Errores de carga, para distinguir con errors.Is en qué fase falló:
+var (
+	// ErrConfigParse: un archivo (o el contenido de LoadFromReader) no es sintácticamente
+	// válido en su formato. Reintentar no sirve de nada sin corregirlo.
+	ErrConfigParse = errors.New("error al leer el archivo de configuración")
+	// ErrConfigDecode: el contenido se leyó, pero algún valor no encaja en el tipo de
+	// su campo (ej: "abc" en un puerto o en una duración).
+	ErrConfigDecode = errors.New("error al decodificar la configuración")
+	// ErrConfigInvalid: la configuración se decodificó, pero no pasa la validación
+	// (Options.Validate, Options.ValidateOnLoad o Options.CustomValidators).
+	ErrConfigInvalid = errors.New("configuración inválida")
 )
 
 // --- ESTRUCTURAS DE CONFIGURACIÓN PÚBLICAS ---
 // Todos los campos deben ser públicos (empezar con Mayúscula) para que Viper pueda llenarlos.
 // Los tags `mapstructure` le dicen a Viper cómo mapear las claves del archivo YAML/JSON.
+// Los campos con `sensitive:"true"` son secretos y se excluyen u ocultan al exportar la configuración.
+// Los campos con `env:"NOMBRE"` se leen también de esa variable de entorno, sin prefijo,
+// que tiene prioridad sobre la derivada de la clave (ej: GOOGLE_CLIENT_ID).
 
 // Config es el struct principal que agrupa toda la configuración.
 // Las claves aquí (application, database, etc.) DEBEN coincidir con las claves de nivel superior en el YAML.
 type Config struct {
-	App    AppConfig   `mapstructure:"application"`
-	DB     DBConfig    `mapstructure:"database"`
-	HTTP   HTTPConfig  `mapstructure:"http"`
-	Redis  RedisConfig `mapstructure:"redis"`
-	OAuth2 OAuthConfig `mapstructure:"google_oauth2"` // Coincide con la clave 'google_oauth2' en YAML
-	Token  TokenConfig `mapstructure:"tokens"`        // Coincide con la clave 'tokens' en YAML
+	App         AppConfig         `mapstructure:"application"`
+	DB          DBConfig          `mapstructure:"database"`
+	HTTP        HTTPConfig        `mapstructure:"http"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	OAuth2      OAuthConfig       `mapstructure:"google_oauth2"` // Coincide con la clave 'google_oauth2' en YAML
+	Token       TokenConfig       `mapstructure:"tokens"`        // Coincide con la clave 'tokens' en YAML
+	Audit       AuditConfig       `mapstructure:"audit"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	Recovery    RecoveryConfig    `mapstructure:"recovery"`
+	Tenancy     TenancyConfig     `mapstructure:"tenancy"`
+	Maintenance MaintenanceConfig `mapstructure:"maintenance"`
+	Migrations  MigrationConfig   `mapstructure:"migrations"`
+	Refresh     RefreshConfig     `mapstructure:"refresh"`
+	Proxy       ProxyConfig       `mapstructure:"proxy"`
+	Health      HealthConfig      `mapstructure:"health"`
+	Kafka       KafkaConfig       `mapstructure:"kafka"`
+
+	// warnings acumula los avisos no fatales de la carga. Ver Warnings().
+	warnings []string
+	// sections contiene las secciones de plugins decodificadas. Ver Section().
+	sections map[string]any
+	// sources indica la fuente de cada campo definido. Ver FlatList().
+	sources map[string]string
+	// file es la ruta del archivo de configuración leído ("" si no se encontró ninguno). Ver SourceFile().
+	file string
+	// settings es la configuración fusionada tal como la tenía Viper. Ver AllSettings().
+	settings map[string]any
+	// redactKeys son las claves de Options.RedactKeys, en minúsculas. Ver Redacted().
+	redactKeys []string
 }
 
-// AppConfig contiene la configuración de la aplicación.
+// AppConfig contiene la configuración de la aplicación. Name solo admite minúsculas,
+// números y guiones: un nombre con mayúsculas, espacios o guiones bajos (ej:
+// "Filingo Maestros"), que antes se aceptaba, hace fallar Validate.
 type AppConfig struct {
-	Name           string `mapstructure:"name"`
-	Environment    string `mapstructure:"environment"`
-	Port           int32  `mapstructure:"port"`
+	Name           string `mapstructure:"name" required:"true" pattern:"^[a-z0-9-]+$" doc:"Nombre de la aplicación, en minúsculas y con guiones"`
+	Environment    string `mapstructure:"environment" doc:"Entorno de ejecución (ej: development, production)"`
+	Port           int32  `mapstructure:"port" min:"1" max:"65535" doc:"Puerto en el que escucha la aplicación"`
 	Version        string `mapstructure:"version"`
 	ProjectRoot    string `mapstructure:"project_root"`
 	GenerationRoot string `mapstructure:"generation_root"`
@@ -61,89 +147,561 @@ type AppConfig struct {
 type DBConfig struct {
 	Driver            string        `mapstructure:"driver"`
 	User              string        `mapstructure:"user"`
-	Password          string        `mapstructure:"password"`
-	Host              string        `mapstructure:"host"`
-	Port              int32         `mapstructure:"port"`
+	Password          string        `mapstructure:"password" sensitive:"true"`
+	Host              string        `mapstructure:"host" required:"true" doc:"Host del servidor de base de datos"`
+	Port              int32         `mapstructure:"port" min:"1" max:"65535" doc:"Puerto del servidor de base de datos"`
 	Name              string        `mapstructure:"name"`
-	MaxConns          int32         `mapstructure:"max_connections"`
-	MinConns          int32         `mapstructure:"min_connections"`
+	MaxConns          int32         `mapstructure:"max_connections" min:"0"`
+	MinConns          int32         `mapstructure:"min_connections" min:"0"`
+	WarmupConns       int32         `mapstructure:"warmup_connections" min:"0"` // Conexiones a abrir al arrancar; no puede superar MaxConns
 	MaxConnLifeTime   time.Duration `mapstructure:"max_connection_life_time"`
 	MaxConnIdleTime   time.Duration `mapstructure:"max_connection_idle_time"`
 	HealthCheckPeriod time.Duration `mapstructure:"health_check_period"`
+	TLS               TLSConfig     `mapstructure:"tls"` // TLS de la conexión con el servidor (CAFile, certificado de cliente)
 }
 
 // HTTPConfig contiene la configuración del servidor HTTP.
 type HTTPConfig struct {
-	Port           int32  `mapstructure:"port"`
-	AllowedOrigins string `mapstructure:"allowed_origins"`
+	Port           int32      `mapstructure:"port" min:"1" max:"65535"`
+	AllowedOrigins []string   `mapstructure:"allowed_origins"` // Lista YAML o texto separado por comas, ej: "https://a.com,https://b.com"
+	TLS            TLSConfig  `mapstructure:"tls"`
+	CORS           CORSConfig `mapstructure:"cors"`
 }
 
 // RedisConfig contiene la configuración de Redis.
 type RedisConfig struct {
-	Address  string `mapstructure:"address"`
-	Password string `mapstructure:"password"`
+	Enabled     bool          `mapstructure:"enabled"` // Si no se indica, true cuando la sección tiene algún valor
+	Address     string        `mapstructure:"address"`
+	Password    string        `mapstructure:"password" sensitive:"true"`
+	DB          int           `mapstructure:"db" min:"0"`        // Índice de la base de datos; 0 por defecto
+	PoolSize    int           `mapstructure:"pool_size" min:"0"` // 0: el valor por defecto del cliente
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`      // 0: el valor por defecto del cliente
 }
 
 // OAuthConfig contiene la configuración para OAuth2.
 type OAuthConfig struct {
-	GoogleClientID     string `mapstructure:"client_id"`
-	GoogleClientSecret string `mapstructure:"client_secret"`
+	Enabled            bool   `mapstructure:"enabled"` // Si no se indica, true cuando la sección tiene algún valor
+	GoogleClientID     string `mapstructure:"client_id" env:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string `mapstructure:"client_secret" sensitive:"true" env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRedirectURI  string `mapstructure:"redirect_uri"`
 	// El session_secret es más para sesiones de cookies,
 	// para PASETO necesitaremos
 	//    una clave simétrica o un par de claves pública/privada
-	SessionSecret string `mapstructure:"session_secret"`
+	SessionSecret string `mapstructure:"session_secret" minlen:"32" sensitive:"true"`
 }
 
 // TokenConfig contiene la configuración para la generación de tokens.
 type TokenConfig struct {
-	Duration      time.Duration `mapstructure:"duration"`
-	PrivateKeyB64 string        `mapstructure:"private_key_b64"`
+	Duration      time.Duration `mapstructure:"duration" maxdur:"24h" validate:"omitempty,mindur=1m"`
+	PrivateKeyB64 string        `mapstructure:"private_key_b64" sensitive:"true"`
 	PublicKeyB64  string        `mapstructure:"public_key_b64"`
 }
 
+// Destinos admitidos en AuditConfig.Destination.
+const (
+	AuditDestinationStdout = "stdout"
+	AuditDestinationFile   = "file"
+	AuditDestinationSyslog = "syslog"
+)
+
+// AuditConfig contiene la configuración del registro de auditoría.
+type AuditConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	Destination        string `mapstructure:"destination"` // "stdout" (por defecto), "file" o "syslog"
+	FilePath           string `mapstructure:"file_path"`   // Obligatorio cuando Destination es "file"
+	IncludeRequestBody bool   `mapstructure:"include_request_body"`
+}
+
+// RecoveryConfig controla cómo el middleware HTTP se recupera de un panic.
+// Por defecto Enabled es true e IncludeStackTrace false, lo seguro para producción.
+type RecoveryConfig struct {
+	Enabled           bool `mapstructure:"enabled"`
+	IncludeStackTrace bool `mapstructure:"include_stack_trace"` // Incluir la traza en la respuesta
+	LogPanics         bool `mapstructure:"log_panics"`
+}
+
+// Valores admitidos en TenancyConfig.
+const (
+	TenancyModeSingle = "single"
+	TenancyModeMulti  = "multi"
+
+	TenantIsolationSchema   = "schema"
+	TenantIsolationDatabase = "database"
+	TenantIsolationRow      = "row"
+)
+
+// TenancyConfig contiene la configuración de multi-tenencia.
+type TenancyConfig struct {
+	Mode              string `mapstructure:"mode"`               // "single" o "multi"
+	DefaultTenant     string `mapstructure:"default_tenant"`     // Obligatorio en modo "single"
+	IsolationStrategy string `mapstructure:"isolation_strategy"` // "schema", "database" o "row"
+}
+
 // ---  OPCIONES DE CARGA ---
 
 // Options permite al usuario de la librería personalizar el proceso de carga.
 type Options struct {
 	ConfigName  string   // ej: "config"
-	ConfigType  string   // ej: "yaml", "json"
+	ConfigType  string   // ej: "yaml", "json", "toml"; ver SupportedConfigTypes
 	ConfigPaths []string // ej: []string{".", "/etc/myapp"}
 	EnvPrefix   string   // ej: "MYAPP"
+
+	// Environment es el entorno de ejecución (ej: "production"); si está vacío se lee de
+	// la variable EnvironmentVar, si se indica. Con un entorno, se carga el archivo
+	// <ConfigName>.<entorno> (ej: config.production.yaml) en lugar de <ConfigName>; si no
+	// existe en ninguna ruta se usa el archivo base, sin error. El entorno también es el
+	// valor por defecto de application.environment.
+	Environment    string
+	EnvironmentVar string // ej: "APP_ENV"; vacío: el entorno solo se indica con Environment
+
+	// DisableEnvKeyReplacer desactiva la sustitución de "." por "_" en los nombres
+	// de las variables de entorno, de modo que "database.host" se lee de
+	// MYAPP_DATABASE.HOST tal cual. Por defecto (false) se usa MYAPP_DATABASE_HOST.
+	DisableEnvKeyReplacer bool
+
+	// EnvNestDelimiter sustituye a "." entre los niveles de una clave en los nombres
+	// de las variables de entorno (ej: con "__", "database.max_connections" se lee de
+	// MYAPP_DATABASE__MAX_CONNECTIONS, como en Kubernetes). Vacío: "_". No se puede
+	// combinar con DisableEnvKeyReplacer. El prefijo se sigue separando con "_".
+	EnvNestDelimiter string
+
+	// FlagSet son los flags de línea de comandos, ya parseados, cuyos valores tienen la
+	// máxima precedencia (por encima del entorno y de los archivos). Solo cuentan los
+	// flags que el usuario ha indicado (Changed); los que conservan su valor por
+	// defecto no sustituyen nada. Cada flag se aplica a la clave de FlagKeys o, si no
+	// está ahí, a la clave con su mismo nombre (ej: un flag "http.port").
+	FlagSet  *pflag.FlagSet
+	FlagKeys map[string]string // nombre del flag -> clave, ej: {"db-host": "database.host"}
+
+	// DotEnvPaths son archivos .env (ej: {".env"}) cuyas variables se cargan en el
+	// entorno del proceso antes de leerlo, así que MYAPP_DATABASE_HOST en un .env se
+	// comporta como si se hubiera exportado. Las variables que ya existen en el entorno
+	// no se sustituyen. Los archivos se vuelven a leer en cada recarga (ReloadEnv,
+	// Watch) y actualizan las variables que definieron antes. Los archivos que no
+	// existen se ignoran; uno malformado es un error.
+	DotEnvPaths []string
+
+	// ForbidSourceConflicts hace que la carga falle si un campo de Config está definido a
+	// la vez en el archivo de configuración (o en ValueDirs) y en una variable de entorno
+	// con valores distintos. Si ambos valores coinciden no se considera un conflicto.
+	ForbidSourceConflicts bool
+
+	// StripEnvQuotes quita los espacios y un par de comillas simples o dobles que
+	// rodeen el valor de las variables de entorno de los campos de Config, como las
+	// que añaden algunos orquestadores (MYAPP_DATABASE_HOST="db" -> db).
+	StripEnvQuotes bool
+
+	// RedactErrors elimina de los errores de carga los valores de los campos secretos
+	// (ej: un error de decodificación que repite una contraseña mal colocada), que se
+	// sustituyen por "********", y convierte en error cualquier pánico durante la
+	// decodificación o los validadores personalizados.
+	RedactErrors bool
+
+	// RedactKeys son claves adicionales (rutas con puntos, ej: "payments.webhook_token")
+	// que Config.Redacted y Config.MarshalJSONRedacted enmascaran igual que los campos
+	// `sensitive:"true"`, aunque no correspondan a ningún campo de Config (ej: claves
+	// dinámicas que solo aparecen en AllSettings). Una sección enmascara todos sus valores.
+	RedactKeys []string
+
+	// CheckPortsAvailable hace que la carga compruebe que los puertos en los que
+	// escuchará la aplicación (application.port y http.port) no estén ocupados,
+	// abriendo y cerrando un listener en cada uno. Desactivado por defecto porque
+	// tiene efectos secundarios.
+	CheckPortsAvailable bool
+
+	// MergeFiles son archivos adicionales que se fusionan en orden sobre el archivo
+	// principal: cada uno sustituye las claves de los anteriores (ej: {"config.base",
+	// "config.local.yaml"}). Admite rutas (con directorio) o nombres de archivo, con o
+	// sin extensión, que se buscan en ConfigPaths. El formato se deduce de la extensión
+	// de cada archivo. Los que no existen se ignoran, como el archivo principal.
+	MergeFiles []string
+
+	// RemoteProvider lee también la configuración de un almacén remoto (etcd, Consul...),
+	// que se fusiona por encima del archivo principal y de MergeFiles: los archivos
+	// locales actúan como base y el almacén como sustituciones. Sin archivo local, la
+	// configuración sale solo del almacén. Ver RemoteProvider.
+	RemoteProvider *RemoteProvider
+
+	// Strict hace que la carga falle si los archivos de configuración contienen claves
+	// que no corresponden a ningún campo de Config ni a una sección registrada con
+	// RegisterSection (ej: "databse:" en lugar de "database:"). El error, que envuelve
+	// ErrConfigDecode, enumera todas las claves desconocidas. Por defecto se ignoran.
+	Strict bool
+
+	// ValueDirs son directorios con un archivo por clave (ej: "database.host"), cuyo
+	// contenido es el valor. Se fusionan en orden sobre el archivo de configuración,
+	// por debajo de las variables de entorno. Pensado para volúmenes de Kubernetes.
+	ValueDirs []string
+
+	// CustomValidators son reglas propias de la aplicación (ej: validaciones entre
+	// campos) que se ejecutan durante la carga, después de Config.Validate().
+	// Todos los errores, propios y de Validate, se devuelven agrupados.
+	CustomValidators []func(*Config) error
+
+	// ValidateOnLoad hace que la carga ejecute Config.Validate() y falle si la
+	// configuración no es válida, aunque no haya CustomValidators.
+	ValidateOnLoad bool
+
+	// Validate hace que la carga valide la configuración con los tags `validate` de
+	// go-playground/validator (ej: validate:"omitempty,mindur=1m"). Si falla, el
+	// error envuelve un validator.ValidationErrors que se puede inspeccionar con errors.As.
+	// Además de los tags estándar admite mindur=D y maxdur=D para campos time.Duration.
+	// En las secciones de Config también se aplican, como reglas del validador, los
+	// tags required, min, max y maxdur de Config.Validate.
+	Validate bool
+
+	// SecretProviders registra proveedores de secretos por esquema: un valor
+	// "esquema://referencia" o "esquema:referencia" se sustituye durante la carga por el
+	// secreto que devuelva el proveedor. "keyring" (el almacén del sistema operativo)
+	// está disponible por defecto; una entrada con el mismo esquema lo reemplaza (ej:
+	// en tests). Para Vault: {"vault": &VaultProvider{...}}.
+	SecretProviders map[string]SecretProvider
+
+	// MutuallyExclusive son grupos de claves (rutas con puntos o secciones completas,
+	// ej: {"redis", "cache.memory"}) de los que como mucho una puede estar definida.
+	// La carga falla si en algún grupo hay más de una clave definida en cualquier fuente.
+	MutuallyExclusive [][]string
+
+	// RequiredKeys son claves (rutas con puntos, ej: "google_oauth2.client_secret") que
+	// deben venir de un archivo o de una variable de entorno; los valores por defecto no
+	// cuentan. Una clave definida explícitamente con un valor vacío sí está presente.
+	// La carga falla con un error que enumera todas las claves ausentes.
+	RequiredKeys []string
+
+	// Defaults son valores por defecto fijos, con la menor precedencia: cualquier
+	// archivo o variable de entorno los sustituye. La clave es la ruta con puntos de
+	// los tags `mapstructure` (ej: {"database.max_connections": 10, "http.port": 8080}).
+	Defaults map[string]any
+
+	// DefaultFuncs calcula valores por defecto dependientes del entorno (ej:
+	// "workers.pool_size": runtime.NumCPU, "application.name": os.Hostname). La clave
+	// es la ruta con puntos; cada función solo se ejecuta si la clave no está definida
+	// en ninguna otra fuente.
+	DefaultFuncs map[string]func() any
+
+	// DeriveMinConns calcula database.min_connections como max(1, max_connections/4)
+	// cuando no está definido en ninguna fuente y max_connections es positivo, en lugar
+	// de dejarlo a 0. Un min_connections explícito, incluido 0, se respeta siempre.
+	DeriveMinConns bool
+
+	// Logger recibe cada aviso de la carga en cuanto se produce (ej: claves obsoletas).
+	// Acepta un *slog.Logger. Si es nil, los avisos solo se acumulan en Config.Warnings().
+	Logger Logger
+
+	// AllowReinit permite que una llamada posterior a Init recargue la configuración
+	// por completo. Si es false (por defecto), volver a llamar a Init con opciones
+	// distintas devuelve ErrAlreadyInitialized en lugar de ignorarse en silencio.
+	AllowReinit bool
+
+	// InitRetries es el número de veces que Init reintenta la carga si falla (ej: un
+	// proveedor de secretos remoto que no responde), y InitRetryBackoff la espera antes
+	// del primer reintento, que se duplica en cada uno. Un archivo de configuración
+	// inexistente no es un fallo, así que no consume reintentos. Ver InitContext.
+	InitRetries      int
+	InitRetryBackoff time.Duration
+
+	// Watch hace que Init vigile el archivo de configuración y, cuando cambia, recargue
+	// la configuración completa y la publique de una vez en el singleton: Get() devuelve
+	// la anterior o la nueva, nunca una a medio cargar. Después se llama a las funciones
+	// registradas con OnChange. Si la recarga falla se conserva la configuración anterior
+	// y el error se envía al Logger. Sin archivo de configuración no hay nada que vigilar.
+	Watch bool
+
+	// ReloadGracePeriod es cuánto espera Watch, tras el último cambio del archivo, antes
+	// de aplicar la recarga; cada cambio nuevo reinicia la espera, así que una ráfaga de
+	// ediciones produce una sola recarga, y deshacer un cambio a tiempo evita aplicarlo.
+	// Si es 0 se usa una espera corta (100ms) que solo agrupa los eventos de un guardado.
+	ReloadGracePeriod time.Duration
+
+	// UseStandardPaths añade a ConfigPaths las rutas de configuración estándar del
+	// sistema operativo para AppName (ej: en Linux $XDG_CONFIG_HOME/<app>, ~/.<app>
+	// y /etc/<app>). Pensado para herramientas de línea de comandos.
+	UseStandardPaths bool
+	AppName          string // ej: "filingo"; obligatorio con UseStandardPaths
+}
+
+// SupportedConfigTypes son los formatos soportados oficialmente en Options.ConfigType:
+// YAML, JSON, TOML, HCL (versión 1) y dotenv. En todos ellos las duraciones pueden
+// escribirse como cadena (ej: "30m") o como número entero de nanosegundos.
+// No incluye "properties" ni "ini": Viper los anuncia, pero ya no sabe decodificarlos.
+var SupportedConfigTypes = []string{"yaml", "yml", "json", "toml", "hcl", "dotenv", "env"}
+
+// IsSupportedConfigType indica si configType es uno de SupportedConfigTypes
+// (ej: "yaml", "json", "toml"). Permite validar Options.ConfigType antes de llamar a Init.
+func IsSupportedConfigType(configType string) bool {
+	return slices.Contains(SupportedConfigTypes, configType)
 }
 
 // --- 3. FUNCIONES PÚBLICAS DE LA LIBRERÍA ---
 
+// Load carga la configuración con las opciones dadas en un struct propio de la
+// aplicación, T, con el mismo proceso que LoadConfig: archivos, entorno (prefijo y
+// sustitución de puntos), flags, secretos, claves *_file, valores por defecto,
+// Strict y Validate. Las claves salen de los tags `mapstructure` de T.
+//
+// Las reglas propias de Config (CustomValidators, ValidateOnLoad, CheckPortsAvailable,
+// los avisos y las secciones de plugins) solo se aplican con Load[Config], que es
+// exactamente LoadConfig. T debe ser un struct.
+func Load[T any](opts Options) (*T, error) {
+	if _, isConfig := any((*T)(nil)).(*Config); isConfig {
+		cfg, err := load(opts)
+		return any(cfg).(*T), err
+	}
+	out := new(T)
+	target := reflect.ValueOf(out).Elem()
+	if target.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configloader: Load necesita un struct, recibió %s", target.Type())
+	}
+	if err := checkOptions(opts); err != nil {
+		return nil, err
+	}
+
+	keys := structKeys(target)
+	envNames := envTags(target)
+	v, err := readSources(opts, keys, envNames)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepare(v, overrides{}, opts, keys, envNames); err != nil {
+		return nil, err
+	}
+	var metadata mapstructure.Metadata
+	if err := v.Unmarshal(out, func(dc *mapstructure.DecoderConfig) { dc.Metadata = &metadata }); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigDecode, err)
+	}
+	if opts.Strict {
+		if unknown := unknownKeys(v, metadata.Unused, keys); len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: claves desconocidas en la configuración: %s", ErrConfigDecode, strings.Join(unknown, ", "))
+		}
+	}
+	if opts.Validate {
+		if err := validateStructTags(out); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigInvalid, err)
+		}
+	}
+	return out, nil
+}
+
+// LoadConfig carga la configuración con las opciones dadas y devuelve un *Config nuevo
+// sin tocar el singleton de Init/Get. Cada llamada produce una configuración
+// independiente, que puede modificarse o compararse con otras sin efectos secundarios.
+// Útil para cargar varias configuraciones en el mismo proceso (tests, multi-tenant...).
+func LoadConfig(opts Options) (*Config, error) {
+	return Load[Config](opts)
+}
+
+// LoadFromReader decodifica la configuración leída de r, en el formato configType
+// (obligatorio, ya que no hay extensión de la que deducirlo: "yaml", "json"...).
+// No consulta archivos ni variables de entorno: solo el contenido de r y los valores
+// por defecto de la librería. Un YAML con varios documentos se fusiona en orden, como
+// al leer un archivo. Útil en tests o para leer de una tubería.
+func LoadFromReader(r io.Reader, configType string) (*Config, error) {
+	if !IsSupportedConfigType(configType) {
+		return nil, fmt.Errorf("tipo de configuración no soportado %q (admitidos: %s)", configType, strings.Join(SupportedConfigTypes, ", "))
+	}
+	// Se guarda el contenido para poder leer de nuevo los documentos YAML adicionales.
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer la configuración: %w", err)
+	}
+	v := newViper()
+	v.SetConfigType(configType)
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	if isYAML("", configType) {
+		// Viper solo lee el primer documento de un YAML; fusionamos el resto en orden.
+		if err := mergeYAMLDocuments(v, bytes.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+		}
+	}
+	return decode(v, overrides{}, Options{ConfigType: configType})
+}
+
 // Init carga la configuración usando las opciones dadas y la almacena como un singleton.
-// Debe ser llamada una sola vez al inicio de la aplicación. Es seguro llamarla múltiples veces.
+// Debe ser llamada una sola vez al inicio de la aplicación. Es seguro llamarla múltiples veces:
+// repetirla con las mismas opciones no hace nada, con opciones distintas devuelve
+// ErrAlreadyInitialized, salvo que opts.AllowReinit sea true, en cuyo caso recarga todo.
+// Si la carga falla, la siguiente llamada vuelve a cargar, con las opciones que reciba.
 func Init(opts Options) error {
+	return InitContext(context.Background(), opts)
+}
+
+// InitContext es como Init, pero los reintentos de opts.InitRetries se interrumpen
+// cuando ctx se cancela o vence su plazo, devolviendo el último error de carga junto
+// con ctx.Err().
+func InitContext(ctx context.Context, opts Options) error {
 	var err error
-	once.Do(func() {
+	first := false
+	mu.RLock()
+	initOnce := once
+	mu.RUnlock()
+	initOnce.Do(func() {
+		first = true
 		// Llama a nuestra lógica de carga interna
-		cfg, loadErr := load(opts)
-		if loadErr != nil {
-			err = loadErr
-			return
-		}
-		instance = cfg
+		err = initInstance(ctx, opts)
 	})
-	return err
+	if first {
+		return err
+	}
+
+	mu.RLock()
+	prev := initOpts
+	mu.RUnlock()
+	switch {
+	case opts.AllowReinit, prev == nil:
+		// prev es nil si ninguna carga ha tenido éxito todavía: se vuelve a intentar.
+		return initInstance(ctx, opts)
+	case !sameOptions(*prev, opts):
+		return ErrAlreadyInitialized
+	}
+	return nil
+}
+
+// initInstance carga la configuración y, solo si tuvo éxito, reemplaza el singleton.
+func initInstance(ctx context.Context, opts Options) error {
+	cfg, err := loadWithRetries(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var watcher *configWatcher
+	if opts.Watch && cfg.file != "" {
+		if watcher, err = startWatcher(opts, cfg.file); err != nil {
+			return fmt.Errorf("no se pudo vigilar el archivo de configuración %q: %w", cfg.file, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if activeWatcher != nil {
+		activeWatcher.close()
+	}
+	activeWatcher = watcher
+	publish(cfg, opts)
+	return nil
+}
+
+// loadWithRetries carga la configuración y, si falla, lo reintenta hasta
+// opts.InitRetries veces, esperando opts.InitRetryBackoff antes del primer reintento
+// y el doble antes de cada uno de los siguientes.
+func loadWithRetries(ctx context.Context, opts Options) (*Config, error) {
+	backoff := opts.InitRetryBackoff
+	for attempt := 0; ; attempt++ {
+		cfg, err := LoadConfig(opts)
+		if err == nil || attempt >= opts.InitRetries {
+			return cfg, err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		}
+		backoff *= 2
+	}
+}
+
+// publish sustituye la instancia del singleton por cfg de una sola vez y despierta
+// a quienes esperan en WaitForInit. Quien la llama debe tener mu bloqueado.
+func publish(cfg *Config, opts Options) {
+	instance = cfg
+	initOpts = &opts
+	close(initDone)
+	initDone = make(chan struct{})
+}
+
+// sameOptions indica si dos Options describen la misma carga.
+// AllowReinit no participa en la comparación porque no afecta al resultado.
+// Las funciones se comparan por identidad, ya que reflect.DeepEqual no sabe compararlas.
+func sameOptions(a, b Options) bool {
+	if !sameFuncs(a.CustomValidators, b.CustomValidators) || !sameFuncMaps(a.DefaultFuncs, b.DefaultFuncs) {
+		return false
+	}
+	a.CustomValidators, b.CustomValidators = nil, nil
+	a.DefaultFuncs, b.DefaultFuncs = nil, nil
+	a.AllowReinit, b.AllowReinit = false, false
+	return reflect.DeepEqual(a, b)
+}
+
+// sameFuncs indica si dos listas contienen exactamente las mismas funciones, en el mismo orden.
+func sameFuncs[F any](a, b []F) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if reflect.ValueOf(a[i]).Pointer() != reflect.ValueOf(b[i]).Pointer() {
+			return false
+		}
+	}
+	return true
+}
+
+// sameFuncMaps indica si dos mapas asocian las mismas claves a las mismas funciones.
+func sameFuncMaps[F any](a, b map[string]F) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, fa := range a {
+		fb, ok := b[key]
+		if !ok || reflect.ValueOf(fa).Pointer() != reflect.ValueOf(fb).Pointer() {
+			return false
+		}
+	}
+	return true
 }
 
 // Get devuelve la instancia singleton de la configuración.
-// Entrará en pánico si Init() no ha sido llamado exitosamente antes.
+// Entrará en pánico si Init() no ha sido llamado exitosamente antes; el mensaje del
+// pánico es fijo y nunca incluye valores de la configuración.
 func Get() *Config {
+	mu.RLock()
+	defer mu.RUnlock()
 	if instance == nil {
 		panic("configloader: la configuración no ha sido inicializada. Llama a Init() primero.")
 	}
 	return instance
 }
 
+// GetCopy es como Get, pero devuelve una copia profunda de la configuración: cada
+// llamada obtiene una instantánea aislada que puede modificar sin afectar a nadie,
+// y que no cambia aunque el singleton se recargue después.
+// Entrará en pánico si Init() no ha sido llamado exitosamente antes.
+func GetCopy() *Config {
+	return Get().Clone()
+}
+
+// WaitForInit bloquea hasta que Init haya cargado la configuración con éxito y la
+// devuelve, o hasta que ctx se cancele, en cuyo caso devuelve ctx.Err().
+// Sirve para goroutines que arrancan en paralelo a Init y no deben llamar a Get() antes de tiempo.
+func WaitForInit(ctx context.Context) (*Config, error) {
+	for {
+		mu.RLock()
+		cfg, done := instance, initDone
+		mu.RUnlock()
+		if cfg != nil {
+			return cfg, nil
+		}
+
+		select {
+		case <-done:
+			// Se publicó una instancia; volvemos a comprobar.
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 // configKey es un tipo privado para usar como clave en el contexto y evitar colisiones.
+// Al ser un struct vacío, convertirlo a interfaz no reserva memoria, así que la búsqueda
+// en FromContext no genera asignaciones (ver BenchmarkFromContext).
 type configKey struct{}
 
 // ToContext devuelve un nuevo contexto que contiene la configuración proporcionada.
+// Si ctx ya contiene esa misma configuración lo devuelve sin cambios, para que varios
+// middlewares que la inyectan no alarguen la cadena de valores del contexto.
 func ToContext(ctx context.Context, cfg *Config) context.Context {
+	if current, ok := FromContext(ctx); ok && current == cfg {
+		return ctx
+	}
 	return context.WithValue(ctx, configKey{}, cfg)
 }
 
@@ -155,44 +713,379 @@ func FromContext(ctx context.Context) (*Config, bool) {
 	return cfg, ok
 }
 
+// MustFromContext devuelve la configuración adjunta a ctx con ToContext o, si no la
+// hay, la del singleton (ver Get). Pensada para handlers que pueden recibir una
+// configuración inyectada por un middleware o usar la global. Entra en pánico, con un
+// mensaje fijo, solo si no hay ninguna de las dos.
+func MustFromContext(ctx context.Context) *Config {
+	if cfg, ok := FromContext(ctx); ok && cfg != nil {
+		return cfg
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	if instance == nil {
+		panic("configloader: no hay configuración en el contexto ni inicializada. Usa ToContext o llama a Init() primero.")
+	}
+	return instance
+}
+
 // --- LÓGICA DE CARGA INTERNA (NO PÚBLICA) ---
 
 // load es la función interna que hace el trabajo pesado con Viper.
 // Load busca, carga y decodifica la configuración en un struct Config.
 // Devuelve un error si algo falla, permitiendo al programa principal manejarlo.
 func load(opts Options) (*Config, error) {
-	v := viper.New()
+	cfg, _, err := loadWithViper(opts, overrides{})
+	return cfg, err
+}
+
+// loadWithViper hace la carga completa y devuelve, además del Config, la instancia
+// de Viper ya poblada, para quien necesite consultarla después (ver Loader). Los valores
+// que la decodificación fija en Viper se registran en ov.
+func loadWithViper(opts Options, ov overrides) (*Config, *viper.Viper, error) {
+	if err := checkOptions(opts); err != nil {
+		return nil, nil, err
+	}
+
+	v, err := readSources(opts, configKeys(), configEnvTags())
+	if err != nil {
+		if opts.RedactErrors {
+			err = redactSecrets(err, nil, opts)
+		}
+		return nil, nil, err
+	}
+	cfg, err := guardedDecode(v, ov, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, v, nil
+}
+
+// checkOptions rechaza las combinaciones de opciones que no tienen sentido antes de leer nada.
+func checkOptions(opts Options) error {
+	if opts.ConfigType != "" && !IsSupportedConfigType(opts.ConfigType) {
+		return fmt.Errorf("tipo de configuración no soportado %q (admitidos: %s)", opts.ConfigType, strings.Join(SupportedConfigTypes, ", "))
+	}
+	if opts.DisableEnvKeyReplacer && opts.EnvNestDelimiter != "" {
+		return errors.New("Options.EnvNestDelimiter no se puede usar con DisableEnvKeyReplacer")
+	}
+	if opts.UseStandardPaths && opts.AppName == "" {
+		return errors.New("Options.AppName es obligatorio cuando UseStandardPaths está activo")
+	}
+	return nil
+}
+
+// readSources crea una instancia de Viper y le carga todas las fuentes
+// configuradas: archivo de configuración, directorios de valores y entorno.
+// keys son los campos hoja del destino, los que compara ForbidSourceConflicts, y
+// envNames sus tags `env` (ver envTags).
+func readSources(opts Options, keys []string, envNames map[string]string) (*viper.Viper, error) {
+	v := newViper()
 
 	// Configurar Viper con las opciones proporcionadas por el usuario.
 	v.SetConfigName(opts.ConfigName)
 	v.SetConfigType(opts.ConfigType)
-	for _, path := range opts.ConfigPaths {
+	for _, path := range searchPaths(opts) {
 		v.AddConfigPath(path)
 	}
 
 	// Configurar la lectura de variables de entorno.
+	if err := loadDotEnv(opts.DotEnvPaths); err != nil {
+		return nil, err
+	}
 	if opts.EnvPrefix != "" {
 		v.SetEnvPrefix(opts.EnvPrefix)
 	}
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
+	if !opts.DisableEnvKeyReplacer {
+		v.SetEnvKeyReplacer(strings.NewReplacer(".", envNestDelimiter(opts)))
+	}
 
-	// Intentar leer el archivo de configuración (si existe).
+	// Intentar leer el archivo de configuración (si existe), el del entorno si lo hay.
 	// No tratamos un archivo no encontrado como un error fatal.
-	if err := v.ReadInConfig(); err != nil {
+	if err := readConfigFile(v, opts); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			if errors.Is(err, fs.ErrPermission) {
+				// El archivo existe pero no podemos leerlo.
+				return nil, notReadableError(v.ConfigFileUsed(), err)
+			}
 			// El error es por otra cosa (ej: un archivo YAML malformado).
-			return nil, fmt.Errorf("error al leer el archivo de configuración: %w", err)
+			return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+		}
+		// Si el archivo no se encuentra, no pasa nada... salvo que Viper no haya podido
+		// buscarlo por falta de permisos, que Viper también reporta como "no encontrado".
+		if err := checkConfigPathsReadable(opts); err != nil {
+			return nil, err
+		}
+	} else {
+		if isYAML(v.ConfigFileUsed(), opts.ConfigType) {
+			// Viper solo lee el primer documento de un YAML; fusionamos el resto en orden.
+			if err := mergeYAMLFile(v, v.ConfigFileUsed()); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+			}
+		}
+		// Si el archivo declara `inherit: <entorno>`, ponemos debajo la configuración de ese entorno.
+		if err := applyInheritance(v, opts.ConfigType); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fusionar los archivos adicionales, cada uno por encima de los anteriores.
+	if err := mergeFiles(v, opts); err != nil {
+		return nil, err
+	}
+
+	// Fusionar la configuración remota por encima de los archivos locales.
+	if err := mergeRemote(v, opts); err != nil {
+		return nil, err
+	}
+
+	// Fusionar los valores montados como un archivo por clave.
+	if err := mergeValueDirs(v, opts.ValueDirs); err != nil {
+		return nil, err
+	}
+
+	// Las variables de entorno se activan al final para poder comparar antes los
+	// valores que vienen solo de archivos.
+	if opts.ForbidSourceConflicts {
+		fileValues := fileSettings(v, keys)
+		v.AutomaticEnv()
+		if err := checkSourceConflicts(fileValues, opts, envNames); err != nil {
+			return nil, err
+		}
+		if err := bindFlags(v, opts); err != nil {
+			return nil, err
 		}
-		// Si el archivo no se encuentra, no pasa nada.
+		return v, nil
+	}
+	v.AutomaticEnv()
+	if err := bindFlags(v, opts); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// prepare aplica a v las reglas posteriores a la lectura que no dependen del tipo
+// destino: comillas del entorno, tags `env`, exclusiones, claves *_file, referencias a
+// secretos, claves obligatorias y valores por defecto. keys son los campos hoja del
+// destino y envNames sus tags `env` (ver envTags). Los valores que fija se registran en
+// ov, y los que fijó una llamada anterior con el mismo ov se deshacen antes.
+func prepare(v *viper.Viper, ov overrides, opts Options, keys []string, envNames map[string]string) error {
+	ov.reset(v)
+	bindEnvKeys(v, opts, keys)
+
+	if opts.StripEnvQuotes {
+		stripEnvQuotes(v, ov, opts, keys)
+	}
+	bindEnvTags(v, ov, opts, envNames)
+
+	if err := checkMutuallyExclusive(v, opts.MutuallyExclusive); err != nil {
+		return err
+	}
+
+	// Sustituir los valores indicados mediante claves *_file por el contenido del archivo.
+	if err := resolveFileKeys(v, ov, opts, keys); err != nil {
+		return err
+	}
+	if err := resolveSecretRefs(v, ov, opts, keys); err != nil {
+		return err
+	}
+
+	if missing := unprovidedKeys(v, opts); len(missing) > 0 {
+		return fmt.Errorf("faltan claves obligatorias en el archivo, el entorno y los flags: %s", strings.Join(missing, ", "))
+	}
+
+	applyDefaults(v, opts)
+	return nil
+}
+
+// decode aplica las reglas posteriores a la lectura (ver prepare) y decodifica v en
+// un Config nuevo, con sus avisos y validaciones.
+// Las variables de entorno se consultan en este momento, así que volver a llamarla
+// con la misma instancia de Viper y el mismo ov recoge sus valores actuales.
+func decode(v *viper.Viper, ov overrides, opts Options) (*Config, error) {
+	keys := configKeys()
+	if err := prepare(v, ov, opts, keys, configEnvTags()); err != nil {
+		return nil, err
 	}
 
 	// Decodificar (Unmarshal) toda la configuración en nuestro struct.
 	// Esta es la "magia" que llena el struct automáticamente.
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("error al decodificar la configuración: %w", err)
+	var metadata mapstructure.Metadata
+	if err := v.Unmarshal(&cfg, func(dc *mapstructure.DecoderConfig) { dc.Metadata = &metadata }); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigDecode, err)
+	}
+	if opts.Strict {
+		if unknown := unknownKeys(v, metadata.Unused, keys); len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: claves desconocidas en la configuración: %s", ErrConfigDecode, strings.Join(unknown, ", "))
+		}
+	}
+	deriveEnabled(v, &cfg)
+	cfg.settings = v.AllSettings()
+	for _, key := range opts.RedactKeys {
+		cfg.redactKeys = append(cfg.redactKeys, strings.ToLower(key))
+	}
+	cfg.file = v.ConfigFileUsed()
+	sections, err := decodeSections(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg.sections = sections
+	cfg.sources = keySources(v, opts)
+	cfg.warnings = deprecationWarnings(v)
+	cfg.warnings = append(cfg.warnings, sectionWarnings(&cfg)...)
+	if opts.Logger != nil {
+		for _, warning := range cfg.warnings {
+			opts.Logger.Warn(warning)
+		}
+	}
+
+	if opts.CheckPortsAvailable {
+		if err := checkPortsAvailable(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Validate {
+		if err := validateStructTags(&cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigInvalid, err)
+		}
+	}
+
+	if opts.ValidateOnLoad || len(opts.CustomValidators) > 0 {
+		if err := runValidators(&cfg, opts.CustomValidators); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigInvalid, err)
+		}
 	}
 
 	return &cfg, nil
 }
+
+// keyProvided indica si key viene de alguna fuente (archivo, entorno o flag) o de
+// opts.Defaults u opts.DefaultFuncs. A diferencia de IsSet, no cuenta los valores por
+// defecto que registra applyDefaults.
+func keyProvided(v *viper.Viper, key string, opts Options) bool {
+	_, fixed := opts.Defaults[key]
+	_, computed := opts.DefaultFuncs[key]
+	return fixed || computed || v.InConfig(key) || envDefined(key, opts) || flagChanged(key, opts)
+}
+
+// builtinDefaults son los valores por defecto de la librería.
+var builtinDefaults = map[string]any{
+	"recovery.enabled":             true,
+	"recovery.include_stack_trace": false,
+	"migrations.table":             "schema_migrations",
+}
+
+// applyDefaults registra los valores por defecto, que tienen la menor precedencia,
+// para las claves que ninguna fuente (archivo, entorno...) ha definido: primero los
+// calculados por opts.DefaultFuncs, después los fijos de opts.Defaults (salvo las
+// claves que ya tienen función), el derivado de opts.DeriveMinConns, el entorno
+// seleccionado (ver Options.Environment) y por último los de la librería. Por eso se
+// llama una vez leídas todas las fuentes; las funciones solo se evalúan si hacen falta.
+func applyDefaults(v *viper.Viper, opts Options) {
+	for _, key := range sortedKeys(opts.DefaultFuncs) {
+		if v.IsSet(key) {
+			// Si solo la define el entorno, Viper no la conoce al decodificar: la registramos.
+			_ = v.BindEnv(key)
+			continue
+		}
+		v.SetDefault(key, opts.DefaultFuncs[key]())
+	}
+	for _, key := range sortedKeys(opts.Defaults) {
+		if _, computed := opts.DefaultFuncs[key]; computed {
+			continue
+		}
+		// SetDefault también registra la clave, así que el entorno puede sustituirla.
+		v.SetDefault(key, opts.Defaults[key])
+	}
+	if opts.DeriveMinConns && !keyProvided(v, "database.min_connections", opts) {
+		// No se usa IsSet, que vería el valor derivado en una decodificación anterior:
+		// se recalcula siempre, porque ReloadEnv puede cambiar max_connections.
+		var derived any
+		if maxConns := v.GetInt32("database.max_connections"); maxConns > 0 {
+			derived = max(1, maxConns/4)
+		}
+		v.SetDefault("database.min_connections", derived)
+	}
+	if env := selectedEnvironment(opts); env != "" && !v.IsSet("application.environment") {
+		v.SetDefault("application.environment", env)
+	}
+	for _, key := range sortedKeys(builtinDefaults) {
+		if !v.IsSet(key) {
+			v.SetDefault(key, builtinDefaults[key])
+		}
+	}
+}
+
+// sortedKeys devuelve las claves de m ordenadas, para recorrerlo de forma estable.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// notReadableError envuelve un error de permisos con un mensaje que identifica la ruta.
+func notReadableError(path string, err error) error {
+	return fmt.Errorf("la ruta de configuración %q no es legible: permiso denegado: %w", path, err)
+}
+
+// checkConfigPathsReadable comprueba, para cada ruta de búsqueda, los nombres de archivo
+// candidatos y devuelve un error si alguno no se pudo examinar por falta de permisos.
+// Así distinguimos "no existe" de "existe, pero no lo podemos leer".
+func checkConfigPathsReadable(opts Options) error {
+	for _, dir := range searchPaths(opts) {
+		for _, ext := range SupportedConfigTypes {
+			candidate := filepath.Join(dir, opts.ConfigName+"."+ext)
+			if _, err := os.Stat(candidate); errors.Is(err, fs.ErrPermission) {
+				return notReadableError(dir, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isYAML indica si el archivo de configuración es YAML, según el tipo
+// explícito o, en su defecto, la extensión del archivo.
+func isYAML(path, configType string) bool {
+	if configType == "" {
+		configType = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	return configType == "yaml" || configType == "yml"
+}
+
+// mergeYAMLFile abre el archivo YAML indicado y fusiona sus documentos adicionales.
+func mergeYAMLFile(v *viper.Viper, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return mergeYAMLDocuments(v, f)
+}
+
+// mergeYAMLDocuments lee todos los documentos (separados por `---`) de r y fusiona
+// en v, en orden, todos menos el primero, que Viper ya ha leído.
+// Así los documentos posteriores sobrescriben las claves de los anteriores.
+func mergeYAMLDocuments(v *viper.Viper, r io.Reader) error {
+	dec := yaml.NewDecoder(r)
+	for i := 0; ; i++ {
+		var doc map[string]any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if i == 0 || doc == nil {
+			continue
+		}
+		if err := v.MergeConfigMap(doc); err != nil {
+			return err
+		}
+	}
+}
@@ -2,9 +2,13 @@
 package configloader
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"sync"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,10 +21,7 @@ func TestInitAndGet_Success(t *testing.T) {
 	// t.Cleanup agenda esta función para que se ejecute AUTOMÁTICAMENTE
 	// cuando este test termine. Así nos aseguramos de que el siguiente test
 	// empiece con un estado limpio.
-	t.Cleanup(func() {
-		instance = nil
-		once = sync.Once{}
-	})
+	t.Cleanup(Reset)
 
 	// --- ARRANGE (Organizar) ---
 	yamlContent := `
@@ -71,10 +72,7 @@ google_oauth2:
 
 func TestInit_ErrorOnMalformedFile(t *testing.T) {
 	// Limpiamos el estado del singleton para este test también.
-	t.Cleanup(func() {
-		instance = nil
-		once = sync.Once{}
-	})
+	t.Cleanup(Reset)
 
 	// Arrange: Creamos un archivo YAML inválido.
 	invalidYamlContent := `
@@ -98,12 +96,42 @@ application:
 	// Assert
 	// Verificamos que Init() devuelve un error, como se esperaba.
 	require.Error(t, initErr, "Init() debería devolver un error con un archivo malformado")
+	assert.ErrorIs(t, initErr, ErrConfigParse)
+	assert.NotErrorIs(t, initErr, ErrConfigDecode)
+}
+
+func TestLoad_ErrorKinds(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		opts    Options
+		want    error
+	}{
+		"sintaxis incorrecta": {content: "application: [sin cerrar\n", want: ErrConfigParse},
+		"tipo incorrecto":     {content: "application:\n  port: \"no-es-un-puerto\"\n", want: ErrConfigDecode},
+		"no válida":           {content: "application:\n  name: \"filingo\"\n", opts: Options{ValidateOnLoad: true}, want: ErrConfigInvalid},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			opts := tc.opts
+			opts.ConfigName, opts.ConfigType = "kinds", "yaml"
+			opts.ConfigPaths = []string{writeTempConfig(t, "kinds.yaml", tc.content)}
+
+			// Act
+			_, err := load(opts)
+
+			// Assert: cada fallo se identifica solo con su propio error.
+			require.Error(t, err)
+			for _, sentinel := range []error{ErrConfigParse, ErrConfigDecode, ErrConfigInvalid} {
+				assert.Equal(t, sentinel == tc.want, errors.Is(err, sentinel), "%v", sentinel)
+			}
+		})
+	}
 }
 
 func TestGet_PanicsIfNotInitialized(t *testing.T) {
 	// Limpiamos por si acaso algún test anterior falló antes de su cleanup.
-	instance = nil
-	once = sync.Once{}
+	Reset()
 
 	// Assert: Verificamos que llamar a Get() antes de Init() causa un pánico.
 	// Esto confirma que nuestra guarda de seguridad funciona.
@@ -111,3 +139,577 @@ func TestGet_PanicsIfNotInitialized(t *testing.T) {
 		Get()
 	}, "Get() debería entrar en pánico si no se ha llamado a Init()")
 }
+
+// writeTempConfig crea un archivo de configuración en un directorio temporal
+// y devuelve la ruta de ese directorio.
+func writeTempConfig(t *testing.T, fileName, content string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, fileName), []byte(content), 0644)
+	require.NoError(t, err, "Falló la creación del archivo de configuración temporal")
+	return tempDir
+}
+
+func TestInit_SecondInitWithDifferentOptionsFails(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange: dos archivos distintos para dos llamadas a Init.
+	firstDir := writeTempConfig(t, "first.yaml", "application:\n  name: \"primera\"\n")
+	secondDir := writeTempConfig(t, "second.yaml", "application:\n  name: \"segunda\"\n")
+	firstOpts := Options{ConfigName: "first", ConfigType: "yaml", ConfigPaths: []string{firstDir}}
+	secondOpts := Options{ConfigName: "second", ConfigType: "yaml", ConfigPaths: []string{secondDir}}
+
+	// Act
+	require.NoError(t, Init(firstOpts))
+	sameErr := Init(firstOpts)
+	differentErr := Init(secondOpts)
+
+	// Assert: repetir las mismas opciones no es un error, cambiarlas sí.
+	assert.NoError(t, sameErr, "Init() con las mismas opciones no debería fallar")
+	assert.ErrorIs(t, differentErr, ErrAlreadyInitialized)
+	assert.Equal(t, "primera", Get().App.Name, "La configuración original no debería cambiar")
+}
+
+func TestInit_AfterFailedInit(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange: la primera llamada falla al leer un archivo malformado.
+	brokenDir := writeTempConfig(t, "broken.yaml", "application: [roto\n")
+	validDir := writeTempConfig(t, "valid.yaml", "application:\n  name: \"valida\"\n")
+	require.Error(t, Init(Options{ConfigName: "broken", ConfigType: "yaml", ConfigPaths: []string{brokenDir}}))
+
+	// Act
+	err := Init(Options{ConfigName: "valid", ConfigType: "yaml", ConfigPaths: []string{validDir}})
+
+	// Assert: la segunda llamada carga de verdad.
+	require.NoError(t, err)
+	assert.Equal(t, "valida", Get().App.Name)
+}
+
+func TestInit_AllowReinitReloads(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	firstDir := writeTempConfig(t, "first.yaml", "application:\n  name: \"primera\"\n")
+	secondDir := writeTempConfig(t, "second.yaml", "application:\n  name: \"segunda\"\n")
+	require.NoError(t, Init(Options{ConfigName: "first", ConfigType: "yaml", ConfigPaths: []string{firstDir}}))
+
+	// Act
+	err := Init(Options{
+		ConfigName:  "second",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{secondDir},
+		AllowReinit: true,
+	})
+
+	// Assert
+	require.NoError(t, err, "Init() con AllowReinit debería recargar sin error")
+	assert.Equal(t, "segunda", Get().App.Name)
+}
+
+func TestLoad_MultiDocumentYAML(t *testing.T) {
+	// Arrange: el segundo documento sobrescribe un valor del primero.
+	yamlContent := `
+application:
+  name: "base"
+  environment: "development"
+database:
+  host: "db-base"
+---
+database:
+  host: "db-override"
+`
+	tempDir := writeTempConfig(t, "multi.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "multi", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db-override", cfg.DB.Host, "El segundo documento debería sobrescribir al primero")
+	assert.Equal(t, "base", cfg.App.Name, "Las claves no sobrescritas deberían conservarse")
+	assert.Equal(t, "development", cfg.App.Environment)
+}
+
+func TestLoad_DisableEnvKeyReplacer(t *testing.T) {
+	// Arrange: la clave debe existir en el archivo para que Viper la busque en el entorno.
+	tempDir := writeTempConfig(t, "env.yaml", "database:\n  host: \"db-file\"\n")
+	t.Setenv("MYAPP_DATABASE.HOST", "db-con-punto")
+	t.Setenv("MYAPP_DATABASE_HOST", "db-con-guion-bajo")
+	opts := Options{ConfigName: "env", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"}
+
+	// Act
+	withReplacer, err := load(opts)
+	require.NoError(t, err)
+	opts.DisableEnvKeyReplacer = true
+	withoutReplacer, err := load(opts)
+	require.NoError(t, err)
+
+	// Assert: sin sustitución, el nombre de la variable conserva el punto literal.
+	assert.Equal(t, "db-con-guion-bajo", withReplacer.DB.Host)
+	assert.Equal(t, "db-con-punto", withoutReplacer.DB.Host)
+}
+
+func TestLoad_EnvNestDelimiter(t *testing.T) {
+	// Arrange: la variable con "_" simple no debe leerse con el separador "__".
+	tempDir := writeTempConfig(t, "nest.yaml", "database:\n  host: \"db-file\"\n")
+	t.Setenv("MYAPP_DATABASE__MAX_CONNECTIONS", "42")
+	t.Setenv("MYAPP_DATABASE__HOST", "db-env")
+	t.Setenv("MYAPP_DATABASE_MAX_CONNECTIONS", "7")
+	opts := Options{ConfigName: "nest", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP", EnvNestDelimiter: "__"}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), cfg.DB.MaxConns)
+	assert.Equal(t, "db-env", cfg.DB.Host)
+	assert.Equal(t, SourceEnv, cfg.sources["database.max_connections"])
+}
+
+func TestLoad_EnvNestDelimiterWithDisabledReplacer(t *testing.T) {
+	// Act
+	_, err := load(Options{ConfigName: "nest", ConfigType: "yaml", EnvNestDelimiter: "__", DisableEnvKeyReplacer: true})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "EnvNestDelimiter")
+}
+
+func TestLoad_UnreadableConfigFile(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("requiere semántica de permisos POSIX y un usuario distinto de root")
+	}
+
+	// Arrange: un archivo de configuración sin permisos de lectura.
+	tempDir := writeTempConfig(t, "secret.yaml", "application:\n  name: \"x\"\n")
+	configPath := filepath.Join(tempDir, "secret.yaml")
+	require.NoError(t, os.Chmod(configPath, 0o000))
+	t.Cleanup(func() { _ = os.Chmod(configPath, 0o644) })
+
+	// Act
+	_, err := load(Options{ConfigName: "secret", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrPermission)
+	assert.Contains(t, err.Error(), "no es legible: permiso denegado")
+	assert.Contains(t, err.Error(), configPath)
+}
+
+func TestLoad_UnreadableConfigDir(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("requiere semántica de permisos POSIX y un usuario distinto de root")
+	}
+
+	// Arrange: un directorio de búsqueda sin permisos.
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"x\"\n")
+	require.NoError(t, os.Chmod(tempDir, 0o000))
+	t.Cleanup(func() { _ = os.Chmod(tempDir, 0o755) })
+
+	// Act
+	_, err := load(Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert: no se confunde con un archivo inexistente.
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrPermission)
+	assert.Contains(t, err.Error(), tempDir)
+}
+
+func TestReset_AllowsInitWithOtherOptions(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	firstDir := writeTempConfig(t, "first.yaml", "application:\n  name: \"primera\"\n")
+	secondDir := writeTempConfig(t, "second.yaml", "application:\n  name: \"segunda\"\n")
+	require.NoError(t, Init(Options{ConfigName: "first", ConfigType: "yaml", ConfigPaths: []string{firstDir}}))
+
+	// Act
+	Reset()
+	err := Init(Options{ConfigName: "second", ConfigType: "yaml", ConfigPaths: []string{secondDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "segunda", Get().App.Name)
+}
+
+func TestReset_ConcurrentWithInit(t *testing.T) {
+	t.Cleanup(Reset)
+	tempDir := writeTempConfig(t, "concurrent.yaml", "application:\n  name: \"x\"\n")
+	opts := Options{ConfigName: "concurrent", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act: Reset e Init a la vez no deberían provocar carreras (ejecutar con -race).
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			Reset()
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, Init(opts))
+	}
+	<-done
+}
+
+func TestWaitForInit_ReturnsAfterDelayedInit(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	// Arrange: Init se ejecuta con retraso en otra goroutine.
+	tempDir := writeTempConfig(t, "delayed.yaml", "application:\n  name: \"tardía\"\n")
+	initErr := make(chan error, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		initErr <- Init(Options{ConfigName: "delayed", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Act
+	cfg, err := WaitForInit(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "tardía", cfg.App.Name)
+	require.NoError(t, <-initErr)
+}
+
+func TestWaitForInit_ContextCancelled(t *testing.T) {
+	Reset()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	cfg, err := WaitForInit(ctx)
+
+	assert.Nil(t, cfg)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestIsSupportedConfigType(t *testing.T) {
+	for _, configType := range []string{"yaml", "json", "toml", "hcl"} {
+		assert.True(t, IsSupportedConfigType(configType), "%q debería estar soportado", configType)
+		assert.Contains(t, SupportedConfigTypes, configType)
+	}
+	assert.False(t, IsSupportedConfigType("txt"))
+	assert.False(t, IsSupportedConfigType("properties"), "Viper ya no sabe decodificarlo")
+}
+
+func TestLoad_UnsupportedConfigType(t *testing.T) {
+	_, err := load(Options{ConfigName: "config", ConfigType: "txt"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "txt")
+}
+
+func TestLoad_DefaultFuncs(t *testing.T) {
+	// Arrange: el archivo define database.host pero no application.name.
+	tempDir := writeTempConfig(t, "dynamic.yaml", "database:\n  host: \"db-file\"\n")
+	calls := map[string]int{}
+	opts := Options{
+		ConfigName:  "dynamic",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		DefaultFuncs: map[string]func() any{
+			"application.name": func() any { calls["application.name"]++; return "host-01" },
+			"database.host":    func() any { calls["database.host"]++; return "db-default" },
+		},
+	}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert: solo se evalúa la función de la clave ausente.
+	require.NoError(t, err)
+	assert.Equal(t, "host-01", cfg.App.Name)
+	assert.Equal(t, "db-file", cfg.DB.Host)
+	assert.Equal(t, 1, calls["application.name"])
+	assert.Equal(t, 0, calls["database.host"], "La función no debería evaluarse si la clave ya está definida")
+
+	// Las variables de entorno siguen ganando a los valores calculados.
+	t.Setenv("MYAPP_APPLICATION_NAME", "desde-env")
+	opts.EnvPrefix = "MYAPP"
+	cfg, err = load(opts)
+	require.NoError(t, err)
+	assert.Equal(t, "desde-env", cfg.App.Name)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	// Arrange: el archivo define http.port; database.max_connections solo tiene valor por defecto.
+	tempDir := writeTempConfig(t, "defaults.yaml", "http:\n  port: 9090\n")
+	t.Setenv("MYAPP_APPLICATION_NAME", "desde-env")
+	opts := Options{
+		ConfigName:  "defaults",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		Defaults: map[string]any{
+			"database.max_connections": 10,
+			"http.port":                8080,
+			"application.name":         "por-defecto",
+		},
+	}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert: defaults < archivo < entorno.
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), cfg.DB.MaxConns)
+	assert.Equal(t, int32(9090), cfg.HTTP.Port, "El archivo gana al valor por defecto")
+	assert.Equal(t, "desde-env", cfg.App.Name, "El entorno gana al valor por defecto")
+}
+
+func TestLoad_DeriveMinConns(t *testing.T) {
+	tests := map[string]struct {
+		database string
+		want     int32
+	}{
+		"min ausente":         {"max_connections: 20", 5},
+		"max pequeño":         {"max_connections: 2", 1},
+		"min explícito":       {"max_connections: 20\n  min_connections: 0", 0},
+		"sin max_connections": {"host: \"db\"", 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tempDir := writeTempConfig(t, "pool.yaml", "database:\n  "+tc.database+"\n")
+
+			// Act
+			cfg, err := load(Options{ConfigName: "pool", ConfigType: "yaml", ConfigPaths: []string{tempDir}, DeriveMinConns: true})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cfg.DB.MinConns)
+		})
+	}
+}
+
+func TestLoader_ReloadEnvDeriveMinConns(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "pool.yaml", "database:\n  max_connections: 8\n")
+	loader, err := NewLoader(Options{ConfigName: "pool", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP", DeriveMinConns: true})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), loader.Config().DB.MinConns)
+
+	// Act: el entorno cambia max_connections y se recarga.
+	t.Setenv("MYAPP_DATABASE_MAX_CONNECTIONS", "40")
+	err = loader.ReloadEnv()
+
+	// Assert: el mínimo se vuelve a derivar del nuevo máximo.
+	require.NoError(t, err)
+	assert.Equal(t, int32(40), loader.Config().DB.MaxConns)
+	assert.Equal(t, int32(10), loader.Config().DB.MinConns)
+}
+
+func TestLoadConfig_IndependentInstances(t *testing.T) {
+	// Arrange: dos archivos distintos en el mismo proceso.
+	dirA := writeTempConfig(t, "tenant.yaml", "application:\n  name: \"a\"\nrate_limit:\n  routes:\n    /api:\n      burst: 1\n")
+	dirB := writeTempConfig(t, "tenant.yaml", "application:\n  name: \"b\"\n")
+
+	// Act
+	cfgA, errA := LoadConfig(Options{ConfigName: "tenant", ConfigType: "yaml", ConfigPaths: []string{dirA}})
+	cfgA2, errA2 := LoadConfig(Options{ConfigName: "tenant", ConfigType: "yaml", ConfigPaths: []string{dirA}})
+	cfgB, errB := LoadConfig(Options{ConfigName: "tenant", ConfigType: "yaml", ConfigPaths: []string{dirB}})
+
+	// Assert
+	require.NoError(t, errA)
+	require.NoError(t, errA2)
+	require.NoError(t, errB)
+	assert.Equal(t, "a", cfgA.App.Name)
+	assert.Equal(t, "b", cfgB.App.Name)
+	cfgA2.RateLimit.Routes["/api"] = RouteLimit{Burst: 99}
+	assert.Equal(t, 1, cfgA.RateLimit.Routes["/api"].Burst, "Modificar una configuración no debería afectar a otra")
+	mu.RLock()
+	defer mu.RUnlock()
+	assert.Nil(t, instance, "LoadConfig no debería tocar el singleton")
+}
+
+func TestLoadFromReader(t *testing.T) {
+	// Arrange
+	yamlContent := "application:\n  name: \"desde-reader\"\ndatabase:\n  port: 5432\n"
+
+	// Act
+	cfg, err := LoadFromReader(strings.NewReader(yamlContent), "yaml")
+	_, errType := LoadFromReader(strings.NewReader(yamlContent), "")
+	_, errSyntax := LoadFromReader(strings.NewReader("application: [roto"), "yaml")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-reader", cfg.App.Name)
+	assert.Equal(t, int32(5432), cfg.DB.Port)
+	assert.True(t, cfg.Recovery.Enabled, "Deberían aplicarse los valores por defecto")
+	assert.Error(t, errType, "El tipo es obligatorio")
+	assert.Error(t, errSyntax)
+}
+
+func TestLoadFromReader_MultiDocumentYAML(t *testing.T) {
+	// Arrange: el segundo documento sobrescribe el puerto del primero.
+	yamlContent := "application:\n  name: \"desde-reader\"\ndatabase:\n  port: 5432\n---\ndatabase:\n  port: 6543\n"
+
+	// Act
+	cfg, err := LoadFromReader(strings.NewReader(yamlContent), "yaml")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-reader", cfg.App.Name)
+	assert.Equal(t, int32(6543), cfg.DB.Port)
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	ctx := context.WithValue(ToContext(context.Background(), cfg), struct{ name string }{"otro"}, "valor")
+
+	// Act
+	got, ok := FromContext(ctx)
+	_, okEmpty := FromContext(context.Background())
+	reinjected := ToContext(ctx, cfg)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Same(t, cfg, got)
+	assert.False(t, okEmpty)
+	assert.Equal(t, ctx, reinjected, "Inyectar la misma configuración no debería crear un contexto nuevo")
+}
+
+func TestMustFromContext(t *testing.T) {
+	t.Cleanup(Reset)
+	Reset()
+
+	// Arrange
+	injected := validConfig()
+	tempDir := writeTempConfig(t, "must.yaml", "application:\n  name: \"global\"\n")
+
+	// Act & Assert: sin contexto ni singleton, pánico.
+	assert.PanicsWithValue(t, "configloader: no hay configuración en el contexto ni inicializada. Usa ToContext o llama a Init() primero.", func() {
+		MustFromContext(context.Background())
+	})
+	assert.Same(t, injected, MustFromContext(ToContext(context.Background(), injected)))
+
+	require.NoError(t, Init(Options{ConfigName: "must", ConfigType: "yaml", ConfigPaths: []string{tempDir}}))
+	assert.Same(t, Get(), MustFromContext(context.Background()), "Sin configuración en el contexto se usa el singleton")
+	assert.Same(t, injected, MustFromContext(ToContext(context.Background(), injected)), "La del contexto tiene prioridad")
+}
+
+// BenchmarkFromContext mide la lectura de la configuración en un contexto con varios
+// valores añadidos encima, como ocurre tras una cadena de middlewares.
+func BenchmarkFromContext(b *testing.B) {
+	type middlewareKey int
+	ctx := ToContext(context.Background(), validConfig())
+	for i := 0; i < 8; i++ {
+		ctx = context.WithValue(ctx, middlewareKey(i), i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := FromContext(ctx); !ok {
+			b.Fatal("no se encontró la configuración")
+		}
+	}
+}
+
+func TestLoad_ConfigFormats(t *testing.T) {
+	// Arrange: la misma configuración en cada formato soportado, con una duración como
+	// cadena (max_connection_life_time) y otra como entero en nanosegundos (max_connection_idle_time).
+	files := map[string]string{
+		"yaml": "database:\n  host: \"db-file\"\n  max_connection_life_time: \"15m\"\n  max_connection_idle_time: 60000000000\n",
+		"json": `{"database": {"host": "db-file", "max_connection_life_time": "15m", "max_connection_idle_time": 60000000000}}`,
+		"toml": "[database]\nhost = \"db-file\"\nmax_connection_life_time = \"15m\"\nmax_connection_idle_time = 60000000000\n",
+		"hcl":  "database {\n  host = \"db-file\"\n  max_connection_life_time = \"15m\"\n  max_connection_idle_time = 60000000000\n}\n",
+	}
+	for configType, content := range files {
+		t.Run(configType, func(t *testing.T) {
+			tempDir := writeTempConfig(t, "formats."+configType, content)
+			opts := Options{ConfigName: "formats", ConfigType: configType, ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"}
+
+			// Act
+			cfg, err := load(opts)
+			require.NoError(t, err)
+			t.Setenv("MYAPP_DATABASE_HOST", "db-env")
+			t.Setenv("MYAPP_DATABASE_MAX_CONNECTION_LIFE_TIME", "1h")
+			fromEnv, err := load(opts)
+			require.NoError(t, err)
+
+			// Assert: las duraciones se decodifican igual y el entorno sustituye las claves con puntos.
+			assert.Equal(t, "db-file", cfg.DB.Host)
+			assert.Equal(t, 15*time.Minute, cfg.DB.MaxConnLifeTime)
+			assert.Equal(t, time.Minute, cfg.DB.MaxConnIdleTime)
+			assert.Equal(t, "db-env", fromEnv.DB.Host)
+			assert.Equal(t, time.Hour, fromEnv.DB.MaxConnLifeTime)
+		})
+	}
+}
+
+// appSettings es un struct propio de una aplicación, distinto de Config.
+type appSettings struct {
+	Service struct {
+		Name    string        `mapstructure:"name" validate:"required"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	} `mapstructure:"service"`
+	Queue struct {
+		URL      string `mapstructure:"url"`
+		Password string `mapstructure:"password"`
+	} `mapstructure:"queue"`
+}
+
+func TestLoad_CustomStruct(t *testing.T) {
+	// Arrange: archivo, entorno y una clave *_file sobre un struct propio.
+	tempDir := writeTempConfig(t, "app.yaml", "service:\n  name: \"worker\"\n  timeout: \"5s\"\nqueue:\n  url: \"amqp://file\"\n")
+	passwordFile := filepath.Join(tempDir, "queue-password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cr3t\n"), 0o600))
+	t.Setenv("MYAPP_QUEUE_URL", "amqp://env")
+	t.Setenv("MYAPP_QUEUE_PASSWORD_FILE", passwordFile)
+
+	// Act
+	settings, err := Load[appSettings](Options{
+		ConfigName:  "app",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		Validate:    true,
+		Strict:      true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "worker", settings.Service.Name)
+	assert.Equal(t, 5*time.Second, settings.Service.Timeout)
+	assert.Equal(t, "amqp://env", settings.Queue.URL)
+	assert.Equal(t, "s3cr3t", settings.Queue.Password)
+}
+
+func TestLoad_CustomStructErrors(t *testing.T) {
+	tempDir := writeTempConfig(t, "app.yaml", "service:\n  timeout: \"5s\"\nqueu:\n  url: \"amqp://file\"\n")
+	opts := Options{ConfigName: "app", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Validate usa los tags del struct propio.
+	opts.Validate = true
+	_, err := Load[appSettings](opts)
+	assert.ErrorIs(t, err, ErrConfigInvalid)
+
+	// Strict compara con los campos del struct propio, no con los de Config.
+	opts.Validate, opts.Strict = false, true
+	_, err = Load[appSettings](opts)
+	assert.ErrorIs(t, err, ErrConfigDecode)
+	assert.Contains(t, err.Error(), "queu")
+
+	// Solo se admiten structs.
+	_, err = Load[map[string]any](Options{ConfigName: "no-existe"})
+	assert.Error(t, err)
+}
+
+func TestLoad_ConfigIsLoadConfig(t *testing.T) {
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"filingo\"\n")
+	opts := Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	viaLoad, err := Load[Config](opts)
+	require.NoError(t, err)
+	viaLoadConfig, err := LoadConfig(opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, viaLoadConfig, viaLoad)
+}
@@ -0,0 +1,80 @@
+// conflicts.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// fileSettings devuelve el valor de cada campo de keys definido en los archivos
+// cargados en v. Debe llamarse antes de v.AutomaticEnv() para no ver el entorno.
+func fileSettings(v *viper.Viper, keys []string) map[string]any {
+	values := map[string]any{}
+	for _, key := range keys {
+		if v.InConfig(key) {
+			values[key] = v.Get(key)
+		}
+	}
+	return values
+}
+
+// checkSourceConflicts compara los valores de los archivos con las variables de
+// entorno de las mismas claves, tanto la derivada de la clave como la de su tag `env`
+// en envNames, y devuelve un *FieldError por cada variable cuyo valor difiera. Los
+// mensajes no incluyen los valores, que pueden ser secretos.
+func checkSourceConflicts(fileValues map[string]any, opts Options, envNames map[string]string) error {
+	var errs []error
+	for _, key := range sortedKeys(fileValues) {
+		names := []string{envVarName(key, opts)}
+		if tag, ok := envNames[key]; ok && tag != names[0] {
+			names = append(names, tag)
+		}
+		for _, name := range names {
+			envValue, ok := os.LookupEnv(name)
+			if !ok || envValue == sourceString(fileValues[key]) {
+				continue
+			}
+			errs = append(errs, &FieldError{
+				Path:    key,
+				Message: fmt.Sprintf("está definida en el archivo y en la variable de entorno %s con valores distintos", name),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// envVarName devuelve la variable de entorno de la que Viper lee key, aplicando
+// el prefijo y el sustituto de "." igual que readSources.
+func envVarName(key string, opts Options) string {
+	if opts.EnvPrefix != "" {
+		key = opts.EnvPrefix + "_" + key
+	}
+	if !opts.DisableEnvKeyReplacer {
+		key = strings.ReplaceAll(key, ".", envNestDelimiter(opts))
+	}
+	return strings.ToUpper(key)
+}
+
+// envNestDelimiter devuelve el separador de niveles en los nombres de las variables
+// de entorno: Options.EnvNestDelimiter o, por defecto, "_".
+func envNestDelimiter(opts Options) string {
+	if opts.EnvNestDelimiter != "" {
+		return opts.EnvNestDelimiter
+	}
+	return "_"
+}
+
+// sourceString representa un valor de archivo tal como se escribiría en una variable
+// de entorno; las listas se unen con comas.
+func sourceString(value any) string {
+	if list, ok := value.([]any); ok {
+		return strings.Join(cast.ToStringSlice(list), ",")
+	}
+	return cast.ToString(value)
+}
@@ -0,0 +1,68 @@
+// conflicts_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ForbidSourceConflicts(t *testing.T) {
+	yamlContent := `
+database:
+  host: "db.local"
+  port: 5432
+google_oauth2:
+  client_id: "id-archivo"
+`
+	tests := map[string]struct {
+		env       map[string]string
+		wantPaths []string
+	}{
+		"valores distintos":  {map[string]string{"MYAPP_DATABASE_HOST": "db.prod"}, []string{"database.host"}},
+		"valores iguales":    {map[string]string{"MYAPP_DATABASE_HOST": "db.local", "MYAPP_DATABASE_PORT": "5432"}, nil},
+		"solo en el entorno": {map[string]string{"MYAPP_APPLICATION_NAME": "filingo"}, nil},
+		"variable del tag":   {map[string]string{"GOOGLE_CLIENT_ID": "id-entorno"}, []string{"google_oauth2.client_id"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tempDir := writeTempConfig(t, "conflicts.yaml", yamlContent)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			_, err := load(Options{
+				ConfigName:            "conflicts",
+				ConfigType:            "yaml",
+				ConfigPaths:           []string{tempDir},
+				EnvPrefix:             "MYAPP",
+				ForbidSourceConflicts: true,
+			})
+
+			// Assert
+			if tc.wantPaths == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tc.wantPaths, fieldErrorPaths(err))
+			assert.NotContains(t, err.Error(), "db.prod", "El error no debería incluir los valores")
+		})
+	}
+}
+
+func TestLoad_SourceConflictsAllowedByDefault(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "conflicts.yaml", "database:\n  host: \"db.local\"\n")
+	t.Setenv("MYAPP_DATABASE_HOST", "db.prod")
+
+	// Act
+	cfg, err := load(Options{ConfigName: "conflicts", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+
+	// Assert: sin la opción, el entorno sigue teniendo prioridad.
+	require.NoError(t, err)
+	assert.Equal(t, "db.prod", cfg.DB.Host)
+}
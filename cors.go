@@ -0,0 +1,140 @@
+// cors.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// corsMethods son los métodos HTTP admitidos en CORSConfig.AllowedMethods.
+var corsMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// corsDefaultMethods son los métodos permitidos si AllowedMethods está vacío:
+// los "simples" de la especificación CORS.
+var corsDefaultMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// CORSConfig contiene la política CORS del servidor HTTP, incluida la respuesta a las
+// peticiones de comprobación previa (preflight). Los orígenes permitidos son los de
+// HTTPConfig.AllowedOrigins. Ver HTTPConfig.CORSHandler.
+type CORSConfig struct {
+	AllowedMethods   []string      `mapstructure:"allowed_methods"` // Vacío: GET, HEAD y POST
+	AllowedHeaders   []string      `mapstructure:"allowed_headers"` // Cabeceras que el cliente puede enviar; "*" admite las que pida
+	ExposedHeaders   []string      `mapstructure:"exposed_headers"` // Cabeceras de la respuesta visibles para el cliente
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"` // Cuánto puede cachear el navegador el preflight; 0 no lo indica
+}
+
+// Validate comprueba que los métodos sean métodos HTTP válidos (en mayúsculas) y que
+// MaxAge no sea negativo. Devuelve todos los errores juntos, o nil.
+func (c *CORSConfig) Validate() error {
+	return errors.Join(c.validate()...)
+}
+
+// validate devuelve un *FieldError por cada problema de la política CORS.
+func (c CORSConfig) validate() []error {
+	var errs []error
+	for _, method := range c.AllowedMethods {
+		if !slices.Contains(corsMethods, method) {
+			errs = append(errs, &FieldError{Path: "http.cors.allowed_methods", Message: fmt.Sprintf("%q no es un método HTTP válido", method)})
+		}
+	}
+	if c.MaxAge < 0 {
+		errs = append(errs, &FieldError{Path: "http.cors.max_age", Message: fmt.Sprintf("no puede ser negativo (valor: %s)", c.MaxAge)})
+	}
+	return errs
+}
+
+// validateCORS valida la política CORS junto con los orígenes permitidos: el origen
+// "*" no se puede combinar con AllowCredentials.
+func (h HTTPConfig) validateCORS() []error {
+	errs := h.CORS.validate()
+	if h.CORS.AllowCredentials && slices.Contains(h.AllowedOrigins, "*") {
+		errs = append(errs, &FieldError{Path: "http.cors.allow_credentials", Message: `no se puede combinar con el origen "*": cualquier sitio podría leer respuestas con credenciales`})
+	}
+	return errs
+}
+
+// CORSHandler envuelve next con la política CORS de h.CORS y los orígenes de
+// AllowedOrigins ("*" admite cualquiera). Las peticiones preflight (OPTIONS con
+// Access-Control-Request-Method) se responden directamente con 204, con las cabeceras
+// CORS solo si el origen y el método están permitidos. El resto de peticiones llegan
+// siempre a next, con las cabeceras CORS añadidas cuando el origen está permitido.
+func (h *HTTPConfig) CORSHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.preflight(w, r)
+			return
+		}
+		header := w.Header()
+		header.Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); h.allowsOrigin(origin) {
+			h.setOrigin(header, origin)
+			if len(h.CORS.ExposedHeaders) > 0 {
+				header.Set("Access-Control-Expose-Headers", strings.Join(h.CORS.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight responde a una petición de comprobación previa.
+func (h *HTTPConfig) preflight(w http.ResponseWriter, r *http.Request) {
+	c := &h.CORS
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+
+	origin := r.Header.Get("Origin")
+	methods := c.AllowedMethods
+	if len(methods) == 0 {
+		methods = corsDefaultMethods
+	}
+	if h.allowsOrigin(origin) && slices.Contains(methods, r.Header.Get("Access-Control-Request-Method")) {
+		h.setOrigin(header, origin)
+		header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if slices.Contains(c.AllowedHeaders, "*") {
+			if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+				header.Set("Access-Control-Allow-Headers", requested)
+			}
+		} else if len(c.AllowedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+		}
+		if c.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge/time.Second)))
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowsOrigin indica si origin está en AllowedOrigins (o si se admite cualquiera).
+func (h *HTTPConfig) allowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	return slices.Contains(h.AllowedOrigins, "*") || slices.Contains(h.AllowedOrigins, origin)
+}
+
+// setOrigin fija Access-Control-Allow-Origin. Un origen admitido solo por "*" recibe
+// "*" y nunca credenciales: repetirlo permitiría a cualquier sitio leer respuestas
+// autenticadas. Con credenciales, los orígenes de la lista se repiten, porque los
+// navegadores rechazan "*" en ese caso.
+func (h *HTTPConfig) setOrigin(header http.Header, origin string) {
+	if slices.Contains(h.AllowedOrigins, "*") && (!h.CORS.AllowCredentials || !slices.Contains(h.AllowedOrigins, origin)) {
+		header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	header.Set("Access-Control-Allow-Origin", origin)
+	if h.CORS.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
@@ -0,0 +1,167 @@
+// cors_test.go
+package configloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corsRequest pasa una petición por el middleware CORS y devuelve la respuesta y si
+// llegó al handler final.
+func corsRequest(httpCfg HTTPConfig, method, origin string, headers map[string]string) (*httptest.ResponseRecorder, bool) {
+	reached := false
+	handler := httpCfg.CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(method, "/api/files", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, reached
+}
+
+func testCORS() HTTPConfig {
+	return HTTPConfig{
+		AllowedOrigins: []string{"https://app.filingo.dev"},
+		CORS: CORSConfig{
+			AllowedMethods: []string{http.MethodGet, http.MethodPut},
+			AllowedHeaders: []string{"Authorization", "Content-Type"},
+			ExposedHeaders: []string{"X-Request-Id"},
+			MaxAge:         10 * time.Minute,
+		},
+	}
+}
+
+func TestCORSHandler_Preflight(t *testing.T) {
+	// Act
+	rec, reached := corsRequest(testCORS(), http.MethodOptions, "https://app.filingo.dev", map[string]string{
+		"Access-Control-Request-Method":  http.MethodPut,
+		"Access-Control-Request-Headers": "Authorization",
+	})
+
+	// Assert: el middleware responde el preflight sin llegar al handler.
+	assert.False(t, reached)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.filingo.dev", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, PUT", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSHandler_PreflightRejected(t *testing.T) {
+	tests := map[string]struct {
+		origin string
+		method string
+	}{
+		"origen no permitido": {"https://evil.example", http.MethodGet},
+		"método no permitido": {"https://app.filingo.dev", http.MethodDelete},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			rec, reached := corsRequest(testCORS(), http.MethodOptions, tc.origin, map[string]string{
+				"Access-Control-Request-Method": tc.method,
+			})
+
+			// Assert: se responde, pero sin cabeceras CORS, así que el navegador bloquea la petición.
+			assert.False(t, reached)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
+
+func TestCORSHandler_ActualRequest(t *testing.T) {
+	// Act
+	allowed, reachedAllowed := corsRequest(testCORS(), http.MethodGet, "https://app.filingo.dev", nil)
+	denied, reachedDenied := corsRequest(testCORS(), http.MethodGet, "https://evil.example", nil)
+
+	// Assert: ambas llegan al handler; solo la permitida lleva cabeceras CORS.
+	assert.True(t, reachedAllowed)
+	assert.Equal(t, "https://app.filingo.dev", allowed.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Request-Id", allowed.Header().Get("Access-Control-Expose-Headers"))
+	assert.True(t, reachedDenied)
+	assert.Empty(t, denied.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", denied.Header().Get("Vary"))
+}
+
+func TestCORSHandler_Wildcards(t *testing.T) {
+	httpCfg := HTTPConfig{AllowedOrigins: []string{"*"}, CORS: CORSConfig{AllowedHeaders: []string{"*"}}}
+	preflight := map[string]string{
+		"Access-Control-Request-Method":  http.MethodPost,
+		"Access-Control-Request-Headers": "X-Custom",
+	}
+
+	// Sin credenciales se responde "*" y se aceptan las cabeceras pedidas.
+	rec, _ := corsRequest(httpCfg, http.MethodOptions, "https://any.example", preflight)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "X-Custom", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Max-Age"))
+
+	// Con credenciales, un origen admitido solo por "*" no se repite ni recibe credenciales.
+	httpCfg.CORS.AllowCredentials = true
+	rec, _ = corsRequest(httpCfg, http.MethodOptions, "https://any.example", preflight)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Los orígenes de la lista sí se repiten con credenciales.
+	httpCfg.AllowedOrigins = append(httpCfg.AllowedOrigins, "https://app.example")
+	rec, _ = corsRequest(httpCfg, http.MethodOptions, "https://app.example", preflight)
+	assert.Equal(t, "https://app.example", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	cors := testCORS().CORS
+	require.NoError(t, cors.Validate())
+
+	cors.AllowedMethods = []string{"GET", "get", "FETCH"}
+	cors.MaxAge = -time.Second
+	err := cors.Validate()
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"http.cors.allowed_methods", "http.cors.allowed_methods", "http.cors.max_age"}, fieldErrorPaths(err))
+
+	// Config.Validate incluye la política CORS, junto con los orígenes de http.allowed_origins.
+	cfg := validConfig()
+	cfg.HTTP.CORS = cors
+	cfg.HTTP.CORS.AllowCredentials = true
+	cfg.HTTP.AllowedOrigins = []string{"*"}
+	assert.Equal(t, []string{"http.cors.allowed_methods", "http.cors.allowed_methods", "http.cors.max_age", "http.cors.allow_credentials"}, fieldErrorPaths(cfg.Validate()))
+}
+
+func TestLoad_CORS(t *testing.T) {
+	// Arrange
+	yamlContent := `
+http:
+  allowed_origins: ["https://app.filingo.dev"]
+  cors:
+    allowed_methods: ["GET", "PUT"]
+    exposed_headers: ["X-Request-Id"]
+    max_age: "10m"
+`
+	tempDir := writeTempConfig(t, "cors.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "cors", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://app.filingo.dev"}, cfg.HTTP.AllowedOrigins)
+	assert.Equal(t, []string{"X-Request-Id"}, cfg.HTTP.CORS.ExposedHeaders)
+	assert.Equal(t, 10*time.Minute, cfg.HTTP.CORS.MaxAge)
+}
@@ -0,0 +1,74 @@
+// crossrules.go
+
+package configloader
+
+import (
+	"strings"
+	"sync"
+)
+
+// CrossFieldError describe la violación de una regla que relaciona campos de
+// distintas secciones. Paths contiene las rutas de todos los campos implicados.
+type CrossFieldError struct {
+	Paths   []string
+	Message string
+}
+
+// Error implementa la interfaz error con el formato "ruta1, ruta2: mensaje".
+func (e *CrossFieldError) Error() string {
+	return strings.Join(e.Paths, ", ") + ": " + e.Message
+}
+
+// CrossRule es una regla de validación entre secciones. Devuelve nil si la
+// configuración la cumple.
+type CrossRule func(c *Config) *CrossFieldError
+
+// builtinCrossRules son las reglas entre secciones que la librería aplica siempre.
+var builtinCrossRules = []CrossRule{
+	tenantIsolationNeedsDatabase,
+	healthDependenciesConfigured,
+}
+
+// crossRules son las reglas registradas por los consumidores con RegisterCrossRule.
+var (
+	crossRulesMu sync.RWMutex
+	crossRules   []CrossRule
+)
+
+// RegisterCrossRule añade una regla entre secciones que Validate() ejecutará
+// después de las incorporadas. Es seguro llamarla desde varias goroutines.
+func RegisterCrossRule(rule CrossRule) {
+	crossRulesMu.Lock()
+	defer crossRulesMu.Unlock()
+	crossRules = append(crossRules, rule)
+}
+
+// validateCrossRules ejecuta las reglas incorporadas y las registradas, en ese orden.
+func validateCrossRules(c *Config) []error {
+	crossRulesMu.RLock()
+	rules := append(append([]CrossRule(nil), builtinCrossRules...), crossRules...)
+	crossRulesMu.RUnlock()
+
+	var errs []error
+	for _, rule := range rules {
+		if err := rule(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// tenantIsolationNeedsDatabase exige un host de base de datos cuando el aislamiento
+// de tenants se hace por esquema o por base de datos.
+func tenantIsolationNeedsDatabase(c *Config) *CrossFieldError {
+	switch c.Tenancy.IsolationStrategy {
+	case TenantIsolationSchema, TenantIsolationDatabase:
+		if c.DB.Host == "" {
+			return &CrossFieldError{
+				Paths:   []string{"tenancy.isolation_strategy", "database.host"},
+				Message: "el aislamiento por " + c.Tenancy.IsolationStrategy + " requiere una base de datos configurada",
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// crossrules_test.go
+package configloader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_BuiltinCrossRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Tenancy = TenancyConfig{Mode: TenancyModeMulti, IsolationStrategy: TenantIsolationSchema}
+
+	// Con base de datos configurada la regla se cumple.
+	require.NoError(t, cfg.Validate())
+
+	// Sin host de base de datos se reportan ambas rutas.
+	cfg.DB.Host = ""
+	err := cfg.Validate()
+	require.Error(t, err)
+	var crossErr *CrossFieldError
+	require.True(t, errors.As(err, &crossErr))
+	assert.Equal(t, []string{"tenancy.isolation_strategy", "database.host"}, crossErr.Paths)
+}
+
+func TestValidate_RegisteredCrossRule(t *testing.T) {
+	// Arrange: el pool de la base de datos debe cubrir el límite de ráfaga global.
+	RegisterCrossRule(func(c *Config) *CrossFieldError {
+		if c.RateLimit.Enabled && int(c.DB.MaxConns) < c.RateLimit.Burst {
+			return &CrossFieldError{
+				Paths:   []string{"database.max_connections", "rate_limit.burst"},
+				Message: "el pool de conexiones debe ser >= que la ráfaga permitida",
+			}
+		}
+		return nil
+	})
+	t.Cleanup(func() {
+		crossRulesMu.Lock()
+		crossRules = nil
+		crossRulesMu.Unlock()
+	})
+	cfg := validConfig()
+	cfg.RateLimit = RateLimitConfig{Enabled: true, RequestsPerSecond: 10, Burst: 5}
+
+	// Caso que cumple la regla (MaxConns = 10).
+	require.NoError(t, cfg.Validate())
+
+	// Caso que la incumple.
+	cfg.RateLimit.Burst = 50
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.max_connections, rate_limit.burst")
+}
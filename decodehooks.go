@@ -0,0 +1,51 @@
+// decodehooks.go
+
+package configloader
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// newViper crea una instancia de Viper con el registro de formatos y las funciones
+// de decodificación de la librería.
+func newViper() *viper.Viper {
+	return viper.NewWithOptions(
+		viper.WithCodecRegistry(codecs),
+		viper.WithDecodeHook(mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			csvSliceHook,
+		)),
+	)
+}
+
+// csvSliceHook decodifica un texto en un campo de tipo slice como una lista separada
+// por comas, con los espacios de cada elemento recortados y sin elementos vacíos.
+// Así MYAPP_HTTP_ALLOWED_ORIGINS="https://a.com, https://b.com" rellena un
+// []string igual que una lista YAML.
+func csvSliceHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+		return data, nil
+	}
+	items := []string{}
+	for _, item := range strings.Split(data.(string), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// bindEnvKeys registra en v las claves de keys cuya variable de entorno existe.
+// AutomaticEnv solo consulta el entorno para las claves que Viper ya conoce, así que
+// sin esto una lista o un campo definido únicamente en el entorno no se decodificaría.
+func bindEnvKeys(v *viper.Viper, opts Options, keys []string) {
+	for _, key := range keys {
+		if envDefined(key, opts) {
+			_ = v.BindEnv(key)
+		}
+	}
+}
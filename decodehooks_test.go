@@ -0,0 +1,78 @@
+// decodehooks_test.go
+package configloader
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_SliceFromEnvOnly(t *testing.T) {
+	// Arrange: la lista no aparece en el archivo, solo en el entorno.
+	tempDir := writeTempConfig(t, "cors.yaml", "http:\n  cors:\n    allow_credentials: true\n")
+	t.Setenv("MYAPP_HTTP_ALLOWED_ORIGINS", "https://a.com, https://b.com,")
+	t.Setenv("MYAPP_HTTP_CORS_ALLOWED_METHODS", "GET,POST")
+
+	// Act
+	cfg, err := load(Options{ConfigName: "cors", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.com", "https://b.com"}, cfg.HTTP.AllowedOrigins)
+	assert.Equal(t, []string{"GET", "POST"}, cfg.HTTP.CORS.AllowedMethods)
+	assert.True(t, cfg.HTTP.CORS.AllowCredentials)
+	assert.Equal(t, SourceEnv, cfg.sources["http.allowed_origins"])
+}
+
+func TestCSVSliceHook(t *testing.T) {
+	tests := map[string][]string{
+		"a,b":       {"a", "b"},
+		" a , b ":   {"a", "b"},
+		"a,,b,":     {"a", "b"},
+		"":          {},
+		"https://x": {"https://x"},
+	}
+	for input, want := range tests {
+		// Act
+		got, err := csvSliceHook(reflect.TypeOf(""), reflect.TypeOf([]string{}), input)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "entrada %q", input)
+	}
+
+	// Los valores que no son texto, o los destinos que no son slices, no se tocan.
+	got, err := csvSliceHook(reflect.TypeOf(""), reflect.TypeOf(""), "a,b")
+	require.NoError(t, err)
+	assert.Equal(t, "a,b", got)
+}
+
+func TestLoad_AllowedOriginsForms(t *testing.T) {
+	want := []string{"https://a.com", "https://b.com"}
+	tests := map[string]struct {
+		content string
+		env     string
+	}{
+		"texto separado por comas": {content: "http:\n  allowed_origins: \" https://a.com, ,https://b.com \"\n"},
+		"lista YAML":               {content: "http:\n  allowed_origins: [\"https://a.com\", \"https://b.com\"]\n"},
+		"variable de entorno":      {content: "http:\n  allowed_origins: \"https://file.com\"\n", env: "https://a.com,https://b.com"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tempDir := writeTempConfig(t, "origins.yaml", tt.content)
+			if tt.env != "" {
+				t.Setenv("MYAPP_HTTP_ALLOWED_ORIGINS", tt.env)
+			}
+
+			// Act
+			cfg, err := load(Options{ConfigName: "origins", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, want, cfg.HTTP.AllowedOrigins)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// deepcopy.go
+
+package configloader
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Clone devuelve una copia profunda de c: los slices, mapas y punteros que contiene
+// (incluidas las secciones de plugins) se copian también, así que modificar la copia
+// nunca afecta al original.
+func (c *Config) Clone() *Config {
+	cp := deepCopy(reflect.ValueOf(c).Elem()).Interface().(Config)
+	// Los campos no exportados no se pueden asignar por reflexión: se copian aquí.
+	cp.warnings = slices.Clone(c.warnings)
+	cp.sources = maps.Clone(c.sources)
+	cp.redactKeys = slices.Clone(c.redactKeys)
+	if c.settings != nil {
+		cp.settings = c.AllSettings()
+	}
+	if c.sections != nil {
+		cp.sections = make(map[string]any, len(c.sections))
+		for key, section := range c.sections {
+			cp.sections[key] = deepCopy(reflect.ValueOf(section)).Interface()
+		}
+	}
+	return &cp
+}
+
+// deepCopy copia v recursivamente. En los structs se parte de una copia superficial
+// (que conserva los campos no exportados) y se sustituyen los exportados por copias.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(deepCopy(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				out.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(deepCopy(v.Elem()))
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,51 @@
+// deepcopy_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_CloneIsIndependent(t *testing.T) {
+	// Arrange: una configuración con slices, mapas y una sección de plugin.
+	original := validConfig()
+	original.Health.Dependencies = []string{"database"}
+	original.RateLimit.Routes = map[string]RouteLimit{"/api": {Burst: 1}}
+	original.warnings = []string{"aviso"}
+	original.sections = map[string]any{"payments": &paymentsConfig{Provider: "stripe"}}
+
+	// Act
+	cp := original.Clone()
+	cp.DB.Password = "cambiada"
+	cp.Health.Dependencies[0] = "redis"
+	cp.RateLimit.Routes["/api"] = RouteLimit{Burst: 99}
+	cp.warnings[0] = "otro"
+	section, _ := cp.Section("payments")
+	section.(*paymentsConfig).Provider = "paypal"
+
+	// Assert
+	assert.Empty(t, original.DB.Password)
+	assert.Equal(t, []string{"database"}, original.Health.Dependencies)
+	assert.Equal(t, 1, original.RateLimit.Routes["/api"].Burst)
+	assert.Equal(t, []string{"aviso"}, original.Warnings())
+	originalSection, _ := original.Section("payments")
+	assert.Equal(t, "stripe", originalSection.(*paymentsConfig).Provider)
+}
+
+func TestGetCopy(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	tempDir := writeTempConfig(t, "copy.yaml", "database:\n  password: \"original\"\n")
+	require.NoError(t, Init(Options{ConfigName: "copy", ConfigType: "yaml", ConfigPaths: []string{tempDir}}))
+
+	// Act
+	cp := GetCopy()
+	cp.DB.Password = "modificada"
+
+	// Assert
+	assert.NotSame(t, Get(), cp)
+	assert.Equal(t, "original", Get().DB.Password)
+}
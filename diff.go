@@ -0,0 +1,86 @@
+// diff.go
+
+package configloader
+
+import "reflect"
+
+// FieldChange es un campo cuyo valor difiere entre dos configuraciones. En los campos
+// `sensitive:"true"`, Old y New son "********": se informa del cambio sin revelar el valor.
+type FieldChange struct {
+	Path string // Ruta con puntos, ej: "database.max_connections".
+	Old  any
+	New  any
+}
+
+// Diff devuelve los campos hoja que cambian de old a new, en el orden en que se declaran
+// en Config, seguidos de los de las secciones de plugins (ordenadas por clave). Un nil
+// se trata como una configuración vacía. Pensado para registrar qué cambió en una recarga:
+//
+//	prev := configloader.Get()
+//	configloader.OnChange(func(cfg *configloader.Config) {
+//		for _, change := range configloader.Diff(prev, cfg) {
+//			logger.Info("configuración modificada", "path", change.Path, "old", change.Old, "new", change.New)
+//		}
+//		prev = cfg
+//	})
+func Diff(old, new *Config) []FieldChange {
+	if old == nil {
+		old = &Config{}
+	}
+	if new == nil {
+		new = &Config{}
+	}
+	changes := diffStructs(reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), "")
+
+	keys := sortedKeys(old.sections)
+	for _, key := range sortedKeys(new.sections) {
+		if _, ok := old.sections[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		oldSection, newSection := sectionValue(old.sections[key]), sectionValue(new.sections[key])
+		switch {
+		case !oldSection.IsValid() && !newSection.IsValid():
+			continue
+		case !oldSection.IsValid():
+			oldSection = reflect.New(newSection.Type()).Elem()
+		case !newSection.IsValid():
+			newSection = reflect.New(oldSection.Type()).Elem()
+		case oldSection.Type() != newSection.Type():
+			continue
+		}
+		changes = append(changes, diffStructs(oldSection, newSection, key)...)
+	}
+	return changes
+}
+
+// diffStructs compara campo a campo dos structs del mismo tipo.
+func diffStructs(old, new reflect.Value, prefix string) []FieldChange {
+	newValues := map[string]reflect.Value{}
+	walkFields(new, prefix, func(path string, _ reflect.StructField, value reflect.Value) {
+		newValues[path] = value
+	})
+	var changes []FieldChange
+	walkFields(old, prefix, func(path string, field reflect.StructField, value reflect.Value) {
+		oldValue, newValue := value.Interface(), newValues[path].Interface()
+		if reflect.DeepEqual(oldValue, newValue) {
+			return
+		}
+		if isSensitive(field) {
+			oldValue, newValue = maskedValue, maskedValue
+		}
+		changes = append(changes, FieldChange{Path: path, Old: oldValue, New: newValue})
+	})
+	return changes
+}
+
+// sectionValue devuelve el struct al que apunta una sección de plugin, o un
+// reflect.Value inválido si no es un puntero a struct.
+func sectionValue(section any) reflect.Value {
+	rv := reflect.ValueOf(section)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv.Elem()
+}
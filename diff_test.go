@@ -0,0 +1,55 @@
+// diff_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	// Arrange
+	old := validConfig()
+	old.DB.Password = "antigua"
+	old.HTTP.AllowedOrigins = []string{"https://a.com"}
+	new := old.Clone()
+	new.DB.MaxConns = 20
+	new.DB.Password = "nueva"
+	new.HTTP.AllowedOrigins = []string{"https://a.com", "https://b.com"}
+	new.Token.Duration = time.Hour
+
+	// Act
+	changes := Diff(old, new)
+
+	// Assert
+	assert.Equal(t, []FieldChange{
+		{Path: "database.password", Old: maskedValue, New: maskedValue},
+		{Path: "database.max_connections", Old: int32(10), New: int32(20)},
+		{Path: "http.allowed_origins", Old: []string{"https://a.com"}, New: []string{"https://a.com", "https://b.com"}},
+		{Path: "tokens.duration", Old: time.Duration(0), New: time.Hour},
+	}, changes)
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	cfg := validConfig()
+	assert.Empty(t, Diff(cfg, cfg.Clone()))
+	assert.Empty(t, Diff(nil, &Config{}))
+}
+
+func TestDiff_Sections(t *testing.T) {
+	// Arrange
+	old := validConfig()
+	old.sections = map[string]any{"payments": &paymentsConfig{Provider: "stripe"}}
+	new := old.Clone()
+	new.sections = map[string]any{"payments": &paymentsConfig{Provider: "adyen", Timeout: time.Second}}
+
+	// Act
+	changes := Diff(old, new)
+
+	// Assert
+	assert.Equal(t, []FieldChange{
+		{Path: "payments.provider", Old: "stripe", New: "adyen"},
+		{Path: "payments.timeout", Old: time.Duration(0), New: time.Second},
+	}, changes)
+}
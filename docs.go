@@ -0,0 +1,27 @@
+// docs.go
+
+package configloader
+
+import "reflect"
+
+// UndocumentedFields devuelve, en el orden en que aparecen en Config, las rutas con
+// puntos (ej: "database.port") de los campos que no tienen un tag `doc:"..."` con su
+// descripción. Pensado para fallar en CI cuando se añade un campo sin documentar:
+//
+//	if fields := configloader.UndocumentedFields(); len(fields) > 0 {
+//		t.Errorf("campos sin documentar: %v", fields)
+//	}
+func UndocumentedFields() []string {
+	return undocumentedFields(reflect.ValueOf(Config{}))
+}
+
+// undocumentedFields recorre los campos hoja de v y devuelve los que no tienen tag `doc`.
+func undocumentedFields(v reflect.Value) []string {
+	var fields []string
+	walkFields(v, "", func(path string, field reflect.StructField, _ reflect.Value) {
+		if field.Tag.Get("doc") == "" {
+			fields = append(fields, path)
+		}
+	})
+	return fields
+}
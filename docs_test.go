@@ -0,0 +1,38 @@
+// docs_test.go
+package configloader
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndocumentedFields(t *testing.T) {
+	// Act
+	fields := UndocumentedFields()
+
+	// Assert: los campos con tag doc no aparecen; los demás sí, con su ruta completa.
+	assert.NotContains(t, fields, "application.name")
+	assert.NotContains(t, fields, "database.host")
+	assert.Contains(t, fields, "database.user")
+	assert.Contains(t, fields, "http.tls.cert_file")
+}
+
+func TestUndocumentedFields_AllDocumented(t *testing.T) {
+	type limits struct {
+		Burst int `mapstructure:"burst" doc:"Ráfaga máxima"`
+	}
+	type section struct {
+		Name   string `mapstructure:"name" doc:"Nombre"`
+		Limits limits `mapstructure:"limits"`
+		Note   string `mapstructure:"note"`
+	}
+
+	assert.Equal(t, []string{"note"}, undocumentedFields(reflect.ValueOf(section{})))
+
+	type documented struct {
+		Limits limits `mapstructure:"limits"`
+	}
+	assert.Empty(t, undocumentedFields(reflect.ValueOf(documented{})))
+}
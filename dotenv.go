@@ -0,0 +1,79 @@
+// dotenv.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/joho/godotenv"
+)
+
+var (
+	// dotEnvMu protege dotEnvVars.
+	dotEnvMu sync.Mutex
+	// dotEnvVars son las variables que loadDotEnv ha definido en el proceso, con el
+	// archivo del que vienen y el valor que les dio. Si la variable conserva ese valor,
+	// nadie la ha cambiado después y se puede actualizar al volver a leer el archivo.
+	dotEnvVars = map[string]dotEnvVar{}
+)
+
+// dotEnvVar es una variable definida por loadDotEnv.
+type dotEnvVar struct {
+	path  string
+	value string
+}
+
+// loadDotEnv carga en el entorno del proceso las variables de los archivos .env de
+// paths que existan, sin sustituir las que ya estaban definidas fuera de ellos (entre
+// dos archivos gana el primero). Las que definió una llamada anterior se actualizan
+// con el contenido actual de su archivo, o se eliminan si ya no aparecen en él, así
+// que Loader.ReloadEnv y las recargas de Options.Watch ven los cambios de los .env.
+func loadDotEnv(paths []string) error {
+	values := map[string]dotEnvVar{}
+	var read []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		fileValues, err := godotenv.Read(path)
+		if err != nil {
+			return fmt.Errorf("error al leer el archivo .env %q: %w", path, err)
+		}
+		read = append(read, path)
+		for name, value := range fileValues {
+			if _, seen := values[name]; !seen {
+				values[name] = dotEnvVar{path: path, value: value}
+			}
+		}
+	}
+
+	dotEnvMu.Lock()
+	defer dotEnvMu.Unlock()
+	for name, previous := range dotEnvVars {
+		if _, ok := values[name]; ok || !slices.Contains(read, previous.path) {
+			continue
+		}
+		if current, ok := os.LookupEnv(name); ok && current == previous.value {
+			_ = os.Unsetenv(name)
+		}
+		delete(dotEnvVars, name)
+	}
+	for name, loaded := range values {
+		current, defined := os.LookupEnv(name)
+		if previous, ok := dotEnvVars[name]; defined && (!ok || current != previous.value) {
+			// La variable viene de fuera de los .env: tiene prioridad.
+			delete(dotEnvVars, name)
+			continue
+		}
+		if err := os.Setenv(name, loaded.value); err != nil {
+			return fmt.Errorf("error al definir la variable %s del archivo .env %q: %w", name, loaded.path, err)
+		}
+		dotEnvVars[name] = loaded
+	}
+	return nil
+}
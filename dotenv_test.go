@@ -0,0 +1,78 @@
+// dotenv_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetEnv elimina la variable durante el test y la restaura al terminar, también
+// si el código bajo prueba la define.
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "")
+	require.NoError(t, os.Unsetenv(name))
+}
+
+func TestLoad_DotEnvPaths(t *testing.T) {
+	// Arrange: un .env con una clave del archivo y otra que ya está en el entorno.
+	unsetEnv(t, "MYAPP_DATABASE_HOST")
+	t.Setenv("MYAPP_APPLICATION_NAME", "desde-shell")
+	tempDir := writeTempConfig(t, "dotenv.yaml", "application:\n  name: \"desde-archivo\"\ndatabase:\n  host: \"db-file\"\n")
+	dotEnv := filepath.Join(tempDir, ".env")
+	require.NoError(t, os.WriteFile(dotEnv, []byte("MYAPP_DATABASE_HOST=db-dotenv\nMYAPP_APPLICATION_NAME=desde-dotenv\n"), 0644))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:  "dotenv",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		DotEnvPaths: []string{filepath.Join(tempDir, "no-existe.env"), dotEnv},
+	})
+
+	// Assert: el .env actúa como el entorno, sin pisar lo ya exportado; el que falta se ignora.
+	require.NoError(t, err)
+	assert.Equal(t, "db-dotenv", cfg.DB.Host)
+	assert.Equal(t, "desde-shell", cfg.App.Name)
+}
+
+func TestLoad_DotEnvMalformed(t *testing.T) {
+	// Arrange
+	dotEnv := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(dotEnv, []byte("MYAPP_DATABASE_HOST='sin cerrar\n"), 0644))
+
+	// Act
+	_, err := load(Options{ConfigName: "no-existe", DotEnvPaths: []string{dotEnv}})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), dotEnv)
+}
+
+func TestLoader_ReloadEnvDotEnv(t *testing.T) {
+	// Arrange
+	unsetEnv(t, "MYAPP_DATABASE_HOST")
+	unsetEnv(t, "MYAPP_DATABASE_USER")
+	t.Setenv("MYAPP_APPLICATION_NAME", "desde-shell")
+	dotEnv := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(dotEnv, []byte("MYAPP_DATABASE_HOST=db-1\nMYAPP_DATABASE_USER=filingo\n"), 0644))
+	loader, err := NewLoader(Options{ConfigName: "no-existe", EnvPrefix: "MYAPP", DotEnvPaths: []string{dotEnv}})
+	require.NoError(t, err)
+	require.Equal(t, "db-1", loader.Config().DB.Host)
+
+	// Act: el .env cambia un valor, quita otro e intenta pisar uno exportado.
+	require.NoError(t, os.WriteFile(dotEnv, []byte("MYAPP_DATABASE_HOST=db-2\nMYAPP_APPLICATION_NAME=desde-dotenv\n"), 0644))
+	err = loader.ReloadEnv()
+
+	// Assert
+	require.NoError(t, err)
+	cfg := loader.Config()
+	assert.Equal(t, "db-2", cfg.DB.Host)
+	assert.Empty(t, cfg.DB.User, "La variable que ya no está en el .env debería desaparecer")
+	assert.Equal(t, "desde-shell", cfg.App.Name, "El entorno exportado sigue teniendo prioridad")
+}
@@ -0,0 +1,29 @@
+// enabled.go
+
+package configloader
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// optionalSections son las claves de las secciones opcionales de Config. Cada una
+// tiene un campo Enabled que, si ninguna fuente define (ej: redis.enabled), se
+// deduce de la propia sección: true si tiene algún campo con valor.
+var optionalSections = []string{"redis", "google_oauth2", "kafka"}
+
+// deriveEnabled rellena el campo Enabled de las secciones opcionales de cfg que no
+// lo tienen definido en v. Un `enabled: false` explícito siempre se respeta.
+func deriveEnabled(v *viper.Viper, cfg *Config) {
+	root := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		key := fieldKey(root.Type().Field(i))
+		if !slices.Contains(optionalSections, key) || v.IsSet(key+".enabled") {
+			continue
+		}
+		section := root.Field(i)
+		section.FieldByName("Enabled").SetBool(!section.IsZero())
+	}
+}
@@ -0,0 +1,46 @@
+// enabled_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_DerivedEnabled(t *testing.T) {
+	tests := map[string]struct {
+		content    string
+		env        map[string]string
+		wantRedis  bool
+		wantOAuth2 bool
+		wantKafka  bool
+	}{
+		"secciones ausentes":           {content: "application:\n  name: \"filingo\"\n"},
+		"sección con valores":          {content: "redis:\n  address: \"localhost:6379\"\n", wantRedis: true},
+		"desactivada explícitamente":   {content: "redis:\n  enabled: false\n  address: \"localhost:6379\"\n"},
+		"activada sin valores":         {content: "google_oauth2:\n  enabled: true\n", wantOAuth2: true},
+		"valor desde el entorno":       {content: "redis:\n  address: \"\"\n", env: map[string]string{"MYAPP_REDIS_ADDRESS": "redis:6379"}, wantRedis: true},
+		"desactivada desde el entorno": {content: "redis:\n  address: \"localhost:6379\"\n", env: map[string]string{"MYAPP_REDIS_ENABLED": "false"}},
+		"kafka con valores":            {content: "kafka:\n  brokers: [\"kafka-1:9092\"]\n", wantKafka: true},
+		"kafka desactivada":            {content: "kafka:\n  enabled: false\n  brokers: [\"kafka-1:9092\"]\n"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tempDir := writeTempConfig(t, "enabled.yaml", tc.content)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			cfg, err := load(Options{ConfigName: "enabled", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRedis, cfg.Redis.Enabled, "redis.enabled")
+			assert.Equal(t, tc.wantOAuth2, cfg.OAuth2.Enabled, "google_oauth2.enabled")
+			assert.Equal(t, tc.wantKafka, cfg.Kafka.Enabled, "kafka.enabled")
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// environment.go
+
+package configloader
+
+import (
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// selectedEnvironment devuelve el entorno de ejecución indicado en opts o, si no se
+// indicó, el de la variable opts.EnvironmentVar. "" significa que no hay entorno
+// seleccionado.
+func selectedEnvironment(opts Options) string {
+	if opts.Environment != "" || opts.EnvironmentVar == "" {
+		return opts.Environment
+	}
+	return os.Getenv(opts.EnvironmentVar)
+}
+
+// readConfigFile lee el archivo de configuración de v. Con un entorno seleccionado
+// busca primero <ConfigName>.<entorno> (ej: config.production.yaml) en las rutas de
+// búsqueda y, si no existe en ninguna, vuelve al archivo base <ConfigName>: olvidar el
+// archivo de un entorno no es un error. Devuelve los mismos errores que v.ReadInConfig.
+func readConfigFile(v *viper.Viper, opts Options) error {
+	env := selectedEnvironment(opts)
+	if env == "" {
+		return v.ReadInConfig()
+	}
+	name := opts.ConfigName
+	if name == "" {
+		name = "config" // El nombre que Viper usa por defecto.
+	}
+	v.SetConfigName(name + "." + env)
+	err := v.ReadInConfig()
+	if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+		return err
+	}
+	v.SetConfigName(name)
+	return v.ReadInConfig()
+}
@@ -0,0 +1,73 @@
+// environment_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_EnvironmentFile(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"base\"\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.production.yaml"), []byte("application:\n  name: \"prod\"\n"), 0644))
+	opts := Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Environment: "production"}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.App.Name)
+	assert.Equal(t, "production", cfg.App.Environment, "El entorno seleccionado rellena application.environment")
+	assert.Equal(t, filepath.Join(tempDir, "config.production.yaml"), cfg.SourceFile())
+}
+
+func TestLoad_EnvironmentFileFallsBackToBase(t *testing.T) {
+	// Arrange: no hay config.staging.yaml.
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"base\"\n")
+	opts := Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Environment: "staging"}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err, "Que falte el archivo del entorno no es un error")
+	assert.Equal(t, "base", cfg.App.Name)
+	assert.Equal(t, "staging", cfg.App.Environment)
+	assert.Equal(t, filepath.Join(tempDir, "config.yaml"), cfg.SourceFile())
+}
+
+func TestLoad_EnvironmentFromEnvVar(t *testing.T) {
+	// Arrange: el archivo del entorno fija su propio application.environment.
+	t.Setenv("APP_ENV", "development")
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"base\"\n")
+	content := "application:\n  name: \"dev\"\n  environment: \"local\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.development.yaml"), []byte(content), 0644))
+
+	// Act
+	cfg, err := load(Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvironmentVar: "APP_ENV"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "dev", cfg.App.Name)
+	assert.Equal(t, "local", cfg.App.Environment, "Un valor explícito tiene prioridad sobre el entorno seleccionado")
+}
+
+func TestLoad_NoEnvironment(t *testing.T) {
+	// Arrange: sin EnvironmentVar, APP_ENV no selecciona ningún entorno.
+	t.Setenv("APP_ENV", "production")
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"base\"\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.production.yaml"), []byte("application:\n  name: \"prod\"\n"), 0644))
+
+	// Act
+	cfg, err := load(Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "base", cfg.App.Name)
+	assert.Empty(t, cfg.App.Environment)
+}
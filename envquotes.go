@@ -0,0 +1,38 @@
+// envquotes.go
+
+package configloader
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// stripEnvQuotes sustituye en v, registrándolo en ov, el valor de cada campo de keys definido por una
+// variable de entorno cuyo contenido lleve espacios o comillas alrededor. Las claves
+// que fija un flag se dejan intactas: el flag tiene prioridad sobre el entorno.
+func stripEnvQuotes(v *viper.Viper, ov overrides, opts Options, keys []string) {
+	for _, key := range keys {
+		if flagChanged(key, opts) {
+			continue
+		}
+		raw, ok := os.LookupEnv(envVarName(key, opts))
+		if !ok {
+			continue
+		}
+		if cleaned := unquote(raw); cleaned != raw {
+			ov.set(v, key, cleaned)
+		}
+	}
+}
+
+// unquote quita los espacios de alrededor y, si el valor empieza y termina con la
+// misma comilla (simple o doble), ese par de comillas. No interpreta escapes.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
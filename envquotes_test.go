@@ -0,0 +1,88 @@
+// envquotes_test.go
+package configloader
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_StripEnvQuotes(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "quotes.yaml", "database:\n  host: \"db.local\"\n")
+	t.Setenv("MYAPP_DATABASE_HOST", `"db.prod"`)
+	opts := Options{ConfigName: "quotes", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"}
+
+	// Act
+	raw, errRaw := load(opts)
+	t.Setenv("MYAPP_DATABASE_PORT", " '6543' ")
+	opts.StripEnvQuotes = true
+	cleaned, err := load(opts)
+
+	// Assert
+	require.NoError(t, errRaw)
+	assert.Equal(t, `"db.prod"`, raw.DB.Host, "Por defecto las comillas se conservan")
+	require.NoError(t, err)
+	assert.Equal(t, "db.prod", cleaned.DB.Host)
+	assert.Equal(t, 6543, int(cleaned.DB.Port))
+}
+
+func TestLoad_StripEnvQuotesKeepsFlag(t *testing.T) {
+	// Arrange: el entorno (con comillas) y un flag definen la misma clave.
+	t.Setenv("MYAPP_DATABASE_HOST", `"db-env"`)
+	fs := pflag.NewFlagSet("filingo", pflag.ContinueOnError)
+	fs.String("db-host", "", "host de la base de datos")
+	require.NoError(t, fs.Parse([]string{"--db-host=db-flag"}))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:     "no-existe",
+		EnvPrefix:      "MYAPP",
+		StripEnvQuotes: true,
+		FlagSet:        fs,
+		FlagKeys:       map[string]string{"db-host": "database.host"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db-flag", cfg.DB.Host, "El flag debería ganar al entorno sin comillas")
+}
+
+func TestLoader_ReloadEnvStripEnvQuotes(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "quotes.yaml", "database:\n  host: \"db-file\"\n")
+	t.Setenv("MYAPP_DATABASE_HOST", `"db-env-1"`)
+	loader, err := NewLoader(Options{ConfigName: "quotes", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP", StripEnvQuotes: true})
+	require.NoError(t, err)
+	require.Equal(t, "db-env-1", loader.Config().DB.Host)
+
+	// Act: cambia el entorno, se recarga, y después se quita la variable.
+	t.Setenv("MYAPP_DATABASE_HOST", `"db-env-2"`)
+	errChanged := loader.ReloadEnv()
+	changed := loader.Config()
+	require.NoError(t, os.Unsetenv("MYAPP_DATABASE_HOST"))
+	errUnset := loader.ReloadEnv()
+
+	// Assert: el valor sin comillas de la carga anterior no se queda fijado.
+	require.NoError(t, errChanged)
+	assert.Equal(t, "db-env-2", changed.DB.Host)
+	require.NoError(t, errUnset)
+	assert.Equal(t, "db-file", loader.Config().DB.Host)
+}
+
+func TestUnquote(t *testing.T) {
+	tests := map[string]string{
+		`"db"`:  "db",
+		`'db'`:  "db",
+		` db `:  "db",
+		`"db'`:  `"db'`,
+		`"`:     `"`,
+		`a"b"c`: `a"b"c`,
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, unquote(input), "entrada %q", input)
+	}
+}
@@ -0,0 +1,48 @@
+// envtags.go
+
+package configloader
+
+import (
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// configEnvTags son los tags `env` de Config, que no cambian: se calculan una vez.
+var configEnvTags = sync.OnceValue(func() map[string]string {
+	return envTags(reflect.ValueOf(Config{}))
+})
+
+// envTags devuelve, para cada campo hoja de v con un tag `env` (ej:
+// env:"GOOGLE_CLIENT_ID"), su ruta con puntos -> nombre de la variable de entorno.
+func envTags(v reflect.Value) map[string]string {
+	names := map[string]string{}
+	walkFields(v, "", func(path string, field reflect.StructField, _ reflect.Value) {
+		if name := field.Tag.Get("env"); name != "" {
+			names[path] = name
+		}
+	})
+	return names
+}
+
+// bindEnvTags enlaza cada clave de names con su variable de entorno, que se lee tal
+// cual, sin EnvPrefix ni sustitución de puntos. La variable derivada automáticamente
+// (ej: MYAPP_GOOGLE_OAUTH2_CLIENT_ID) sigue funcionando, pero si ambas existen gana la
+// del tag: como Viper consulta AutomaticEnv antes que los enlaces, su valor se fija
+// explícitamente, registrándolo en ov, salvo que un flag indicado por el usuario
+// defina la clave.
+func bindEnvTags(v *viper.Viper, ov overrides, opts Options, names map[string]string) {
+	for _, key := range sortedKeys(names) {
+		_ = v.BindEnv(key, names[key])
+		value, ok := os.LookupEnv(names[key])
+		if !ok || flagChanged(key, opts) {
+			continue
+		}
+		if opts.StripEnvQuotes {
+			value = unquote(value)
+		}
+		ov.set(v, key, value)
+	}
+}
@@ -0,0 +1,98 @@
+// envtags_test.go
+package configloader
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_EnvTagOverride(t *testing.T) {
+	// Arrange: las dos variables existen; la del tag debe ganar.
+	tempDir := writeTempConfig(t, "envtag.yaml", "google_oauth2:\n  client_id: \"desde-archivo\"\n")
+	t.Setenv("MYAPP_GOOGLE_OAUTH2_CLIENT_ID", "desde-prefijo")
+	t.Setenv("GOOGLE_CLIENT_ID", "desde-tag")
+	opts := Options{ConfigName: "envtag", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-tag", cfg.OAuth2.GoogleClientID)
+	assert.Equal(t, SourceEnv, cfg.sources["google_oauth2.client_id"])
+}
+
+func TestLoad_EnvTagFallsBackToDerivedName(t *testing.T) {
+	// Arrange
+	unsetEnv(t, "GOOGLE_CLIENT_ID")
+	unsetEnv(t, "GOOGLE_CLIENT_SECRET")
+	t.Setenv("MYAPP_GOOGLE_OAUTH2_CLIENT_ID", "desde-prefijo")
+	t.Setenv("GOOGLE_CLIENT_SECRET", "secreto-tag")
+	opts := Options{ConfigName: "no-existe", ConfigType: "yaml", ConfigPaths: []string{t.TempDir()}, EnvPrefix: "MYAPP"}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-prefijo", cfg.OAuth2.GoogleClientID, "Sin la variable del tag se usa la derivada")
+	assert.Equal(t, "secreto-tag", cfg.OAuth2.GoogleClientSecret, "La variable del tag basta aunque la clave no esté en ninguna otra fuente")
+}
+
+func TestLoader_ReloadEnvEnvTag(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "envtag.yaml", "google_oauth2:\n  client_id: \"desde-archivo\"\n")
+	t.Setenv("GOOGLE_CLIENT_ID", "desde-tag")
+	loader, err := NewLoader(Options{ConfigName: "envtag", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+	require.NoError(t, err)
+	require.Equal(t, "desde-tag", loader.Config().OAuth2.GoogleClientID)
+
+	// Act: se quita la variable del tag y se recarga.
+	require.NoError(t, os.Unsetenv("GOOGLE_CLIENT_ID"))
+	err = loader.ReloadEnv()
+
+	// Assert: el valor del tag de la carga anterior no se queda fijado.
+	require.NoError(t, err)
+	assert.Equal(t, "desde-archivo", loader.Config().OAuth2.GoogleClientID)
+}
+
+func TestLoad_EnvTagBelowFlags(t *testing.T) {
+	// Arrange
+	t.Setenv("GOOGLE_CLIENT_ID", "desde-tag")
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("client-id", "", "")
+	require.NoError(t, fs.Parse([]string{"--client-id=desde-flag"}))
+	opts := Options{
+		ConfigName:  "no-existe",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{t.TempDir()},
+		FlagSet:     fs,
+		FlagKeys:    map[string]string{"client-id": "google_oauth2.client_id"},
+	}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-flag", cfg.OAuth2.GoogleClientID)
+}
+
+func TestLoad_EnvTagCustomStruct(t *testing.T) {
+	// Arrange
+	type serviceConfig struct {
+		Token string `mapstructure:"token" env:"PLATFORM_TOKEN"`
+	}
+	t.Setenv("PLATFORM_TOKEN", "abc")
+
+	// Act
+	cfg, err := Load[serviceConfig](Options{ConfigName: "no-existe", ConfigType: "yaml", ConfigPaths: []string{t.TempDir()}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "abc", cfg.Token)
+}
@@ -0,0 +1,58 @@
+// fields.go
+
+package configloader
+
+import (
+	"reflect"
+	"strings"
+)
+
+// walkFields recorre recursivamente los campos exportados del struct v y llama a fn
+// por cada campo "hoja" (todo lo que no sea un struct anidado), pasándole su ruta en
+// notación de puntos construida con los tags `mapstructure` (ej: "database.max_connections").
+// Es el recorrido común que reutilizan la validación y el resto de utilidades por reflexión.
+func walkFields(v reflect.Value, prefix string, fn func(path string, field reflect.StructField, value reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := fieldKey(field)
+		if key == "-" {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		value := v.Field(i)
+		if isSection(field.Type) {
+			walkFields(value, path, fn)
+			continue
+		}
+		fn(path, field, value)
+	}
+}
+
+// fieldKey devuelve la clave con la que Viper/mapstructure mapea el campo:
+// el nombre del tag `mapstructure` o, si no lo tiene, el nombre del campo en minúsculas.
+func fieldKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// isSection indica si el tipo es una sección anidada de configuración (un struct),
+// es decir, algo que hay que recorrer en lugar de tratar como un valor.
+func isSection(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct
+}
+
+// isSensitive indica si el campo está marcado como secreto con `sensitive:"true"`.
+func isSensitive(field reflect.StructField) bool {
+	return field.Tag.Get("sensitive") == "true"
+}
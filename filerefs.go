@@ -0,0 +1,57 @@
+// filerefs.go
+
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// fileKeySuffix es el sufijo de las claves que apuntan a un archivo con el valor real.
+const fileKeySuffix = "_file"
+
+// resolveFileKeys aplica la convención *_FILE de los secretos de Docker/Kubernetes:
+// si para un campo de keys (ej: "database.password") existe la clave hermana
+// "database.password_file", en cualquier fuente, se lee ese archivo y su contenido,
+// sin espacios ni saltos de línea alrededor, pasa a ser el valor del campo, con
+// prioridad sobre el valor directo. Un flag indicado para el campo tiene prioridad
+// sobre el archivo. El valor se fija en v registrándolo en ov.
+//
+// Solo se consideran campos del struct destino, para no confundir con rutas que ya son
+// campos propios (ej: "http.tls.cert_file" no define "http.tls.cert").
+func resolveFileKeys(v *viper.Viper, ov overrides, opts Options, keys []string) error {
+	for _, key := range keys {
+		if flagChanged(key, opts) {
+			continue
+		}
+		fileKey := key + fileKeySuffix
+		path := v.GetString(fileKey)
+		if path == "" {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error al leer el archivo indicado en %s: %w", fileKey, err)
+		}
+		ov.resolve(v, key, strings.TrimSpace(string(content)))
+	}
+	return nil
+}
+
+// configKeys devuelve las rutas con puntos de todos los campos hoja de Config.
+func configKeys() []string {
+	return structKeys(reflect.ValueOf(Config{}))
+}
+
+// structKeys devuelve las rutas con puntos de todos los campos hoja del struct v.
+func structKeys(v reflect.Value) []string {
+	var keys []string
+	walkFields(v, "", func(path string, _ reflect.StructField, _ reflect.Value) {
+		keys = append(keys, path)
+	})
+	return keys
+}
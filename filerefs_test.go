@@ -0,0 +1,77 @@
+// filerefs_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_FileKeyFromConfig(t *testing.T) {
+	// Arrange: el secreto vive en un archivo aparte, como en /run/secrets.
+	secretPath := filepath.Join(t.TempDir(), "db_pass")
+	require.NoError(t, os.WriteFile(secretPath, []byte("s3cr3t\n"), 0600))
+	yamlContent := "database:\n  password: \"ignorado\"\n  password_file: \"" + secretPath + "\"\n"
+	tempDir := writeTempConfig(t, "secrets.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "secrets", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.DB.Password, "El contenido del archivo debería reemplazar al valor directo")
+}
+
+func TestLoad_FileKeyFromEnv(t *testing.T) {
+	// Arrange
+	secretPath := filepath.Join(t.TempDir(), "redis_pass")
+	require.NoError(t, os.WriteFile(secretPath, []byte("  redis-secret \n"), 0600))
+	t.Setenv("MYAPP_REDIS_PASSWORD_FILE", secretPath)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "no-existe", ConfigType: "yaml", EnvPrefix: "MYAPP"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "redis-secret", cfg.Redis.Password)
+}
+
+func TestLoad_FileKeyUnreadable(t *testing.T) {
+	// Arrange: el archivo referenciado no existe.
+	yamlContent := "database:\n  password_file: \"" + filepath.Join(t.TempDir(), "no-existe") + "\"\n"
+	tempDir := writeTempConfig(t, "missing.yaml", yamlContent)
+
+	// Act
+	_, err := load(Options{ConfigName: "missing", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.password_file")
+}
+
+func TestLoad_FileKeyKeepsFlag(t *testing.T) {
+	// Arrange: un flag indica la contraseña y el archivo apunta a un secreto.
+	secretPath := filepath.Join(t.TempDir(), "db_pass")
+	require.NoError(t, os.WriteFile(secretPath, []byte("s3cr3t"), 0600))
+	tempDir := writeTempConfig(t, "secrets.yaml", "database:\n  password_file: \""+secretPath+"\"\n")
+	fs := pflag.NewFlagSet("filingo", pflag.ContinueOnError)
+	fs.String("db-password", "", "contraseña de la base de datos")
+	require.NoError(t, fs.Parse([]string{"--db-password=flag-pass"}))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:  "secrets",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		FlagSet:     fs,
+		FlagKeys:    map[string]string{"db-password": "database.password"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "flag-pass", cfg.DB.Password, "El flag debería ganar al archivo *_file")
+}
@@ -0,0 +1,47 @@
+// flags.go
+
+package configloader
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// bindFlags enlaza en v los flags de opts.FlagSet que el usuario ha indicado, cada uno
+// con su clave (ver flagKey). Los flags sin cambiar no se enlazan: Viper usaría su
+// valor por defecto cuando ninguna otra fuente define la clave.
+func bindFlags(v *viper.Viper, opts Options) error {
+	if opts.FlagSet == nil {
+		return nil
+	}
+	var err error
+	opts.FlagSet.Visit(func(flag *pflag.Flag) {
+		if bindErr := v.BindPFlag(flagKey(flag.Name, opts), flag); bindErr != nil && err == nil {
+			err = fmt.Errorf("error al enlazar el flag %q: %w", flag.Name, bindErr)
+		}
+	})
+	return err
+}
+
+// flagKey devuelve la clave a la que se aplica el flag name: la de opts.FlagKeys o,
+// si no tiene, el propio nombre del flag.
+func flagKey(name string, opts Options) string {
+	if key, ok := opts.FlagKeys[name]; ok {
+		return key
+	}
+	return name
+}
+
+// flagChanged indica si algún flag indicado por el usuario se aplica a key.
+func flagChanged(key string, opts Options) bool {
+	if opts.FlagSet == nil {
+		return false
+	}
+	changed := false
+	opts.FlagSet.Visit(func(flag *pflag.Flag) {
+		changed = changed || flagKey(flag.Name, opts) == key
+	})
+	return changed
+}
@@ -0,0 +1,60 @@
+// flags_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_FlagSet(t *testing.T) {
+	// Arrange: el archivo y el entorno definen valores que los flags deben sustituir.
+	yamlContent := `
+http:
+  port: 8080
+database:
+  host: "db-file"
+  port: 5432
+`
+	tempDir := writeTempConfig(t, "flags.yaml", yamlContent)
+	t.Setenv("MYAPP_DATABASE_HOST", "db-env")
+	fs := pflag.NewFlagSet("filingo", pflag.ContinueOnError)
+	fs.Int32("http.port", 9000, "puerto HTTP")
+	fs.String("db-host", "db-flag-default", "host de la base de datos")
+	fs.Int32("db-port", 6543, "puerto de la base de datos")
+	require.NoError(t, fs.Parse([]string{"--http.port=9090", "--db-host=db-flag"}))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:  "flags",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		FlagSet:     fs,
+		FlagKeys:    map[string]string{"db-host": "database.host", "db-port": "database.port"},
+	})
+
+	// Assert: los flags indicados ganan al archivo y al entorno; el que no se indicó no cuenta.
+	require.NoError(t, err)
+	assert.Equal(t, int32(9090), cfg.HTTP.Port)
+	assert.Equal(t, "db-flag", cfg.DB.Host)
+	assert.Equal(t, int32(5432), cfg.DB.Port)
+	assert.Equal(t, SourceFlag, cfg.sources["database.host"])
+	assert.Equal(t, SourceFile, cfg.sources["database.port"])
+}
+
+func TestLoad_FlagDefaultDoesNotApply(t *testing.T) {
+	// Arrange: ninguna fuente define la clave y el flag no se indica.
+	fs := pflag.NewFlagSet("filingo", pflag.ContinueOnError)
+	fs.String("db-host", "db-flag-default", "host de la base de datos")
+	require.NoError(t, fs.Parse(nil))
+
+	// Act
+	cfg, err := load(Options{ConfigName: "no-existe", FlagSet: fs, FlagKeys: map[string]string{"db-host": "database.host"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, cfg.DB.Host)
+}
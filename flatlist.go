@@ -0,0 +1,94 @@
+// flatlist.go
+
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// maskedValue sustituye al valor de los campos secretos en los listados.
+const maskedValue = "********"
+
+// Orígenes posibles de un valor en ConfigEntry.Source.
+const (
+	SourceFlag    = "flag"    // Flag de línea de comandos (Options.FlagSet).
+	SourceEnv     = "env"     // Variable de entorno.
+	SourceFile    = "file"    // Archivo de configuración o ValueDirs.
+	SourceDefault = "default" // Valor por defecto de la librería o de Options.DefaultFuncs.
+)
+
+// ConfigEntry es una fila del listado plano de la configuración.
+type ConfigEntry struct {
+	Path   string // Ruta con puntos, ej: "database.host".
+	Value  string // Valor formateado; enmascarado si Secret y no se pidieron los secretos.
+	Type   string // Tipo Go del campo, ej: "int32" o "time.Duration".
+	Secret bool   // El campo está marcado con `sensitive:"true"`.
+	Source string // SourceEnv, SourceFile, SourceDefault o "" si se desconoce (ej: sin definir).
+}
+
+// FlatList devuelve todos los campos hoja de la configuración como una lista ordenada
+// por Path, pensada para paneles de administración. Si includeSecrets es false, los
+// campos secretos siguen apareciendo pero con el valor enmascarado.
+func (c *Config) FlatList(includeSecrets bool) []ConfigEntry {
+	var entries []ConfigEntry
+	walkFields(reflect.ValueOf(c).Elem(), "", func(path string, field reflect.StructField, value reflect.Value) {
+		entry := ConfigEntry{
+			Path:   path,
+			Value:  fmt.Sprint(value.Interface()),
+			Type:   field.Type.String(),
+			Secret: isSensitive(field),
+			Source: c.sources[path],
+		}
+		if entry.Secret && !includeSecrets {
+			entry.Value = maskedValue
+		}
+		entries = append(entries, entry)
+	})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
+
+// SourceFile devuelve la ruta del archivo de configuración que se leyó, tal como la
+// resolvió Viper entre Options.ConfigPaths, o "" si la configuración salió solo del
+// entorno y de los valores por defecto. No incluye los archivos de Options.MergeFiles.
+func (c *Config) SourceFile() string {
+	return c.file
+}
+
+// keySources determina de qué fuente sale cada campo de Config definido en v.
+// Sigue la prioridad de Viper: flags, entorno, después archivos y por último valores por defecto.
+func keySources(v *viper.Viper, opts Options) map[string]string {
+	sources := map[string]string{}
+	for _, key := range configKeys() {
+		switch {
+		case flagChanged(key, opts):
+			sources[key] = SourceFlag
+		case envDefined(key, opts):
+			sources[key] = SourceEnv
+		case v.InConfig(key):
+			sources[key] = SourceFile
+		case v.IsSet(key):
+			sources[key] = SourceDefault
+		}
+	}
+	return sources
+}
+
+// envDefined indica si existe la variable de entorno de la que Viper lee key, o la
+// de su tag `env` si el campo de Config lo tiene.
+func envDefined(key string, opts Options) bool {
+	if _, ok := os.LookupEnv(envVarName(key, opts)); ok {
+		return true
+	}
+	name, tagged := configEnvTags()[key]
+	if !tagged {
+		return false
+	}
+	_, ok := os.LookupEnv(name)
+	return ok
+}
@@ -0,0 +1,64 @@
+// flatlist_test.go
+package configloader
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_FlatList(t *testing.T) {
+	// Arrange
+	yamlContent := `
+database:
+  host: "db.local"
+  port: 5432
+  password: "s3cr3t"
+`
+	tempDir := writeTempConfig(t, "flat.yaml", yamlContent)
+	t.Setenv("MYAPP_DATABASE_PORT", "6543")
+	cfg, err := load(Options{ConfigName: "flat", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+	require.NoError(t, err)
+
+	// Act
+	masked := cfg.FlatList(false)
+	revealed := cfg.FlatList(true)
+
+	// Assert
+	byPath := map[string]ConfigEntry{}
+	for _, entry := range masked {
+		byPath[entry.Path] = entry
+	}
+	assert.Equal(t, ConfigEntry{Path: "database.host", Value: "db.local", Type: "string", Source: SourceFile}, byPath["database.host"])
+	assert.Equal(t, ConfigEntry{Path: "database.port", Value: "6543", Type: "int32", Source: SourceEnv}, byPath["database.port"])
+	assert.Equal(t, ConfigEntry{Path: "recovery.enabled", Value: "true", Type: "bool", Source: SourceDefault}, byPath["recovery.enabled"])
+	assert.Equal(t, ConfigEntry{Path: "database.password", Value: maskedValue, Type: "string", Secret: true, Source: SourceFile}, byPath["database.password"])
+	assert.Empty(t, byPath["application.name"].Source, "Un campo sin definir no tiene fuente")
+	assert.True(t, sort.SliceIsSorted(masked, func(i, j int) bool { return masked[i].Path < masked[j].Path }))
+
+	for _, entry := range revealed {
+		if entry.Path == "database.password" {
+			assert.Equal(t, "s3cr3t", entry.Value)
+		}
+	}
+}
+
+func TestLoad_SourceFile(t *testing.T) {
+	// Arrange: el archivo está en el segundo directorio de búsqueda.
+	emptyDir := t.TempDir()
+	tempDir := writeTempConfig(t, "found.yaml", "application:\n  name: \"filingo\"\n")
+
+	// Act
+	found, errFound := load(Options{ConfigName: "found", ConfigType: "yaml", ConfigPaths: []string{emptyDir, tempDir}})
+	missing, errMissing := load(Options{ConfigName: "found", ConfigType: "yaml", ConfigPaths: []string{emptyDir}})
+
+	// Assert
+	require.NoError(t, errFound)
+	assert.Equal(t, filepath.Join(tempDir, "found.yaml"), found.SourceFile())
+	assert.Equal(t, found.SourceFile(), found.View().SourceFile())
+	require.NoError(t, errMissing)
+	assert.Empty(t, missing.SourceFile(), "Sin archivo la configuración sale solo del entorno y de los valores por defecto")
+}
@@ -0,0 +1,57 @@
+// hcl.go
+
+package configloader
+
+import (
+	"errors"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spf13/viper"
+)
+
+// codecs es el registro de formatos que usan todas las instancias de Viper de la
+// librería. Viper 1.20 ya no decodifica HCL por sí mismo; el resto de formatos
+// (yaml, json, toml, dotenv) los sigue resolviendo su registro por defecto.
+var codecs = func() *viper.DefaultCodecRegistry {
+	r := viper.NewCodecRegistry()
+	_ = r.RegisterCodec("hcl", hclCodec{})
+	return r
+}()
+
+// hclCodec decodifica archivos HCL (versión 1). Solo lectura: la librería nunca
+// escribe configuración en HCL.
+type hclCodec struct{}
+
+// Decode implementa viper.Decoder.
+func (hclCodec) Decode(b []byte, v map[string]any) error {
+	var raw map[string]any
+	if err := hcl.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for key, value := range raw {
+		v[key] = flattenHCLBlocks(value)
+	}
+	return nil
+}
+
+// Encode implementa viper.Encoder.
+func (hclCodec) Encode(map[string]any) ([]byte, error) {
+	return nil, errors.New("configloader: la escritura en formato HCL no está soportada")
+}
+
+// flattenHCLBlocks convierte los bloques de HCL, que el decodificador devuelve como
+// listas de mapas (`database { ... }` es []map[string]any), en mapas anidados como
+// los de YAML. Si un bloque se repite, sus claves se fusionan y gana el último.
+func flattenHCLBlocks(value any) any {
+	blocks, ok := value.([]map[string]any)
+	if !ok {
+		return value
+	}
+	merged := make(map[string]any)
+	for _, block := range blocks {
+		for key, inner := range block {
+			merged[key] = flattenHCLBlocks(inner)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,43 @@
+// hcl_test.go
+package configloader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromReader_HCLNestedBlocks(t *testing.T) {
+	// Arrange: bloques anidados y un bloque repetido, que HCL decodifica como listas.
+	content := `
+application {
+  name = "filingo"
+}
+http {
+  port = 8080
+  tls {
+    client_auth = "none"
+  }
+}
+http {
+  allowed_origins = "https://filingo.dev"
+}
+`
+
+	// Act
+	cfg, err := LoadFromReader(strings.NewReader(content), "hcl")
+
+	// Assert: los bloques se fusionan como mapas y gana el último.
+	require.NoError(t, err)
+	assert.Equal(t, "filingo", cfg.App.Name)
+	assert.Equal(t, int32(8080), cfg.HTTP.Port)
+	assert.Equal(t, "none", cfg.HTTP.TLS.ClientAuth)
+	assert.Equal(t, []string{"https://filingo.dev"}, cfg.HTTP.AllowedOrigins)
+}
+
+func TestHCLCodec_EncodeUnsupported(t *testing.T) {
+	_, err := hclCodec{}.Encode(map[string]any{"a": 1})
+	assert.Error(t, err)
+}
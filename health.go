@@ -0,0 +1,68 @@
+// health.go
+
+package configloader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// HealthConfig controla qué dependencias comprueba el endpoint de salud.
+type HealthConfig struct {
+	// Dependencies son las dependencias a comprobar (ej: "database", "redis"). Cada
+	// una debe estar configurada en su sección.
+	Dependencies []string `mapstructure:"dependencies"`
+}
+
+// healthDependencies asocia cada dependencia admitida con el campo que indica si su
+// sección está configurada y con la comprobación correspondiente.
+var healthDependencies = map[string]struct {
+	path       string
+	configured func(c *Config) bool
+}{
+	"database": {"database.host", func(c *Config) bool { return c.DB.Host != "" }},
+	"redis":    {"redis.address", func(c *Config) bool { return c.Redis.Address != "" }},
+	"kafka":    {"kafka.brokers", func(c *Config) bool { return len(c.Kafka.Brokers) > 0 }},
+}
+
+// HealthDependencies devuelve una copia de las dependencias que debe comprobar el
+// endpoint de salud, en el orden configurado.
+func (c *Config) HealthDependencies() []string {
+	return slices.Clone(c.Health.Dependencies)
+}
+
+// validate comprueba que todas las dependencias sean conocidas.
+func (h HealthConfig) validate() []error {
+	var errs []error
+	for _, dep := range h.Dependencies {
+		if _, ok := healthDependencies[dep]; !ok {
+			errs = append(errs, &FieldError{
+				Path:    "health.dependencies",
+				Message: fmt.Sprintf("%q no es una dependencia conocida (usa %s)", dep, strings.Join(sortedKeys(healthDependencies), " o ")),
+			})
+		}
+	}
+	return errs
+}
+
+// healthDependenciesConfigured exige que cada dependencia de salud tenga su sección
+// configurada: comprobar "redis" sin dirección de Redis fallaría siempre.
+func healthDependenciesConfigured(c *Config) *CrossFieldError {
+	paths := []string{"health.dependencies"}
+	var missing []string
+	for _, dep := range c.Health.Dependencies {
+		check, ok := healthDependencies[dep]
+		if ok && !check.configured(c) {
+			paths = append(paths, check.path)
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &CrossFieldError{
+		Paths:   paths,
+		Message: "las dependencias de salud " + strings.Join(missing, ", ") + " no están configuradas",
+	}
+}
@@ -0,0 +1,56 @@
+// health_test.go
+package configloader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_HealthDependencies(t *testing.T) {
+	// Arrange
+	yamlContent := `
+database:
+  host: "db.local"
+redis:
+  address: "localhost:6379"
+health:
+  dependencies: ["database", "redis"]
+`
+	tempDir := writeTempConfig(t, "health.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "health", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	deps := cfg.HealthDependencies()
+	assert.Equal(t, []string{"database", "redis"}, deps)
+	deps[0] = "modificada"
+	assert.Equal(t, "database", cfg.Health.Dependencies[0], "Debería devolver una copia")
+}
+
+func TestValidate_HealthDependencyNotConfigured(t *testing.T) {
+	// Arrange: se pide comprobar redis sin que esté configurado.
+	cfg := validConfig()
+	cfg.Health.Dependencies = []string{"database", "redis"}
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	var crossErr *CrossFieldError
+	require.True(t, errors.As(err, &crossErr))
+	assert.Equal(t, []string{"health.dependencies", "redis.address"}, crossErr.Paths)
+
+	cfg.Redis.Address = "localhost:6379"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_HealthDependencyUnknown(t *testing.T) {
+	cfg := validConfig()
+	cfg.Health.Dependencies = []string{"mongo"}
+	assert.Equal(t, []string{"health.dependencies"}, fieldErrorPaths(cfg.Validate()))
+}
@@ -0,0 +1,92 @@
+// inherit.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// inheritKey es la clave de nivel superior con la que un archivo declara de qué
+// entorno hereda (ej: `inherit: production` dentro de config.staging.yaml).
+const inheritKey = "inherit"
+
+// applyInheritance resuelve la herencia entre entornos del archivo ya leído en v.
+//
+// Si el archivo declara `inherit: <entorno>`, se carga <base>.<entorno>.<ext> del mismo
+// directorio (donde <base> es el nombre del archivo hasta el primer punto) como base, que
+// a su vez puede heredar de otro entorno. El resultado se fusiona de forma que el entorno
+// más lejano queda debajo y el archivo actual encima. Un ciclo en la cadena es un error.
+func applyInheritance(v *viper.Viper, configType string) error {
+	if !v.InConfig(inheritKey) {
+		return nil
+	}
+	file := v.ConfigFileUsed()
+	current, err := readFileSettings(file, configType)
+	if err != nil {
+		return err
+	}
+	parent := cast.ToString(current[inheritKey])
+	if parent == "" {
+		return nil
+	}
+
+	dir, ext := filepath.Dir(file), filepath.Ext(file)
+	base, currentEnv, _ := strings.Cut(strings.TrimSuffix(filepath.Base(file), ext), ".")
+	visited := []string{currentEnv}
+
+	// Recorremos la cadena de padres, del más cercano al más lejano.
+	layers := []map[string]any{current}
+	for parent != "" {
+		if slices.Contains(visited, parent) {
+			return fmt.Errorf("herencia cíclica de entornos: %s -> %s", strings.Join(visited, " -> "), parent)
+		}
+		visited = append(visited, parent)
+
+		settings, err := readFileSettings(filepath.Join(dir, base+"."+parent+ext), configType)
+		if err != nil {
+			return fmt.Errorf("error al cargar el entorno %q del que se hereda: %w", parent, err)
+		}
+		layers = append(layers, settings)
+		parent = cast.ToString(settings[inheritKey])
+	}
+
+	// Fusionamos del más lejano al actual y aplicamos el resultado sobre v, cuya capa
+	// de archivo solo contiene el archivo actual, que sigue ganando.
+	merged := newViper()
+	for i := len(layers) - 1; i >= 0; i-- {
+		if err := merged.MergeConfigMap(layers[i]); err != nil {
+			return err
+		}
+	}
+	return v.MergeConfigMap(merged.AllSettings())
+}
+
+// readFileSettings lee un único archivo de configuración (con todos sus documentos,
+// si es YAML) y devuelve su contenido, sin entorno ni valores por defecto.
+func readFileSettings(path, configType string) (map[string]any, error) {
+	v := newViper()
+	v.SetConfigFile(path)
+	if configType != "" {
+		v.SetConfigType(configType)
+	}
+	if err := v.ReadInConfig(); err != nil {
+		var parseErr viper.ConfigParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("%w %q: %w", ErrConfigParse, path, err)
+		}
+		return nil, err
+	}
+	if isYAML(path, configType) {
+		if err := mergeYAMLFile(v, path); err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrConfigParse, path, err)
+		}
+	}
+	return v.AllSettings(), nil
+}
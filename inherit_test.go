@@ -0,0 +1,66 @@
+// inherit_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_InheritEnvironment(t *testing.T) {
+	// Arrange: staging hereda de production y solo cambia el host.
+	dir := t.TempDir()
+	production := `
+application:
+  name: "filingo"
+  environment: "production"
+database:
+  host: "db-prod"
+  max_connections: 50
+`
+	staging := `
+inherit: production
+application:
+  environment: "staging"
+database:
+  host: "db-staging"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.production.yaml"), []byte(production), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.staging.yaml"), []byte(staging), 0644))
+
+	// Act
+	cfg, err := load(Options{ConfigName: "config.staging", ConfigType: "yaml", ConfigPaths: []string{dir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db-staging", cfg.DB.Host, "El archivo actual debería ganar")
+	assert.Equal(t, "staging", cfg.App.Environment)
+	assert.Equal(t, int32(50), cfg.DB.MaxConns, "Lo no sobrescrito se hereda de production")
+	assert.Equal(t, "filingo", cfg.App.Name)
+}
+
+func TestLoad_InheritCycle(t *testing.T) {
+	// Arrange: staging -> production -> staging.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.production.yaml"), []byte("inherit: staging\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.staging.yaml"), []byte("inherit: production\n"), 0644))
+
+	// Act
+	_, err := load(Options{ConfigName: "config.staging", ConfigType: "yaml", ConfigPaths: []string{dir}})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "staging -> production -> staging")
+}
+
+func TestLoad_InheritMissingParent(t *testing.T) {
+	tempDir := writeTempConfig(t, "config.staging.yaml", "inherit: production\n")
+
+	_, err := load(Options{ConfigName: "config.staging", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "production")
+}
@@ -0,0 +1,72 @@
+// jsondump.go
+
+package configloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// MarshalJSONRedacted devuelve la configuración como JSON, con las claves de los tags
+// `mapstructure`, para un endpoint de depuración. Los campos `sensitive:"true"` y las
+// claves de Options.RedactKeys se incluyen con el valor enmascarado y las duraciones se
+// escriben legibles (ej: "1h30m0s"). Si la configuración se leyó de un archivo, su ruta
+// se añade en "source_file".
+func (c *Config) MarshalJSONRedacted() ([]byte, error) {
+	out := redactedValue(reflect.ValueOf(c).Elem()).(map[string]any)
+	for _, key := range c.redactKeys {
+		maskSettingsKey(out, key)
+	}
+	if c.file != "" {
+		out["source_file"] = c.file
+	}
+	return json.Marshal(out)
+}
+
+// redactedValue convierte v en valores que encoding/json sabe escribir, enmascarando
+// los secretos y convirtiendo las duraciones en texto.
+func redactedValue(v reflect.Value) any {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		out := make(map[string]any, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || fieldKey(field) == "-" {
+				continue
+			}
+			if isSensitive(field) {
+				out[fieldKey(field)] = maskedValue
+				continue
+			}
+			out[fieldKey(field)] = redactedValue(v.Field(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = redactedValue(iter.Value())
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactedValue(v.Index(i))
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,50 @@
+// jsondump_test.go
+package configloader
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_MarshalJSONRedacted(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.DB.Password = "s3cr3t"
+	cfg.DB.MaxConnLifeTime = 90 * time.Minute
+	cfg.RateLimit.Routes = map[string]RouteLimit{"/api": {RequestsPerSecond: 2, Burst: 5}}
+
+	// Act
+	data, err := cfg.MarshalJSONRedacted()
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	database := decoded["database"].(map[string]any)
+	assert.Equal(t, maskedValue, database["password"], "Los secretos se enmascaran pero siguen apareciendo")
+	assert.Equal(t, "1h30m0s", database["max_connection_life_time"])
+	assert.Equal(t, "localhost", database["host"])
+	routes := decoded["rate_limit"].(map[string]any)["routes"].(map[string]any)
+	assert.Equal(t, float64(5), routes["/api"].(map[string]any)["burst"])
+	assert.NotContains(t, decoded, "source_file", "Sin archivo leído no se incluye la ruta")
+}
+
+func TestConfig_MarshalJSONRedactedSourceFile(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.file = "/etc/filingo/config.yaml"
+
+	// Act
+	data, err := cfg.MarshalJSONRedacted()
+
+	// Assert
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "/etc/filingo/config.yaml", decoded["source_file"])
+}
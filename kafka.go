@@ -0,0 +1,58 @@
+// kafka.go
+
+package configloader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Mecanismos admitidos en KafkaSASLConfig.Mechanism.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// saslMechanisms son los valores válidos de KafkaSASLConfig.Mechanism.
+var saslMechanisms = []string{SASLPlain, SASLScramSHA256, SASLScramSHA512}
+
+// KafkaConfig contiene la configuración de los brokers de Kafka con los que la
+// aplicación publica y consume eventos.
+type KafkaConfig struct {
+	Enabled bool            `mapstructure:"enabled"` // Si no se indica, true cuando la sección tiene algún valor
+	Brokers []string        `mapstructure:"brokers"` // Lista YAML o texto separado por comas, ej: "kafka-1:9092,kafka-2:9092"
+	GroupID string          `mapstructure:"group_id"`
+	Topic   string          `mapstructure:"topic"`
+	SASL    KafkaSASLConfig `mapstructure:"sasl"`
+	TLS     TLSConfig       `mapstructure:"tls"`
+}
+
+// KafkaSASLConfig contiene la autenticación SASL con los brokers. Sin Mechanism no
+// se usa SASL.
+type KafkaSASLConfig struct {
+	Mechanism string `mapstructure:"mechanism"` // "PLAIN", "SCRAM-SHA-256" o "SCRAM-SHA-512"
+	User      string `mapstructure:"user"`
+	Password  string `mapstructure:"password" sensitive:"true"`
+}
+
+// DialString devuelve los brokers separados por comas (ej: "kafka-1:9092,kafka-2:9092"),
+// el formato que esperan la mayoría de clientes de Kafka en su lista de bootstrap servers.
+func (k KafkaConfig) DialString() string {
+	return strings.Join(k.Brokers, ",")
+}
+
+// validate comprueba que el mecanismo SASL sea conocido y que, si se usa, tenga usuario.
+func (k KafkaConfig) validate() []error {
+	if k.SASL.Mechanism == "" {
+		return nil
+	}
+	if !slices.Contains(saslMechanisms, k.SASL.Mechanism) {
+		return []error{&FieldError{Path: "kafka.sasl.mechanism", Message: fmt.Sprintf("%q no es válido (usa %s)", k.SASL.Mechanism, strings.Join(saslMechanisms, ", "))}}
+	}
+	if k.SASL.User == "" {
+		return []error{&FieldError{Path: "kafka.sasl.user", Message: "es obligatorio cuando se indica sasl.mechanism"}}
+	}
+	return nil
+}
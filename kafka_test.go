@@ -0,0 +1,95 @@
+// kafka_test.go
+package configloader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_KafkaBrokers(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		env     string
+	}{
+		"lista YAML":          {content: "kafka:\n  brokers: [\"kafka-1:9092\", \"kafka-2:9092\"]\n"},
+		"variable de entorno": {content: "kafka:\n  topic: \"events\"\n", env: "kafka-1:9092, kafka-2:9092"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tempDir := writeTempConfig(t, "kafka.yaml", tt.content)
+			if tt.env != "" {
+				t.Setenv("MYAPP_KAFKA_BROKERS", tt.env)
+			}
+
+			// Act
+			cfg, err := load(Options{ConfigName: "kafka", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.Kafka.Brokers)
+			assert.Equal(t, "kafka-1:9092,kafka-2:9092", cfg.Kafka.DialString())
+		})
+	}
+}
+
+func TestKafkaConfig_PasswordRedacted(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.Kafka.SASL = KafkaSASLConfig{Mechanism: SASLPlain, User: "svc", Password: "s3cr3t"}
+
+	// Act
+	redacted := cfg.Redacted()
+
+	// Assert
+	assert.Equal(t, maskedValue, redacted.Kafka.SASL.Password)
+	assert.Equal(t, "svc", redacted.Kafka.SASL.User)
+	assert.NotContains(t, cfg.String(), "s3cr3t")
+	assert.NotContains(t, fmt.Sprintf("%+v", cfg.Kafka), "s3cr3t")
+	assert.NotContains(t, fmt.Sprintf("%+v", cfg.Kafka.SASL), "s3cr3t")
+	assert.Contains(t, fmt.Sprintf("%v", cfg.Kafka.SASL), "User:svc")
+}
+
+func TestKafkaConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		sasl KafkaSASLConfig
+		want []string
+	}{
+		"sin SASL":           {sasl: KafkaSASLConfig{}},
+		"SCRAM con usuario":  {sasl: KafkaSASLConfig{Mechanism: SASLScramSHA512, User: "svc"}},
+		"mecanismo inválido": {sasl: KafkaSASLConfig{Mechanism: "GSSAPI", User: "svc"}, want: []string{"kafka.sasl.mechanism"}},
+		"sin usuario":        {sasl: KafkaSASLConfig{Mechanism: SASLPlain}, want: []string{"kafka.sasl.user"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			cfg.Kafka.SASL = tt.sasl
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			assert.Equal(t, tt.want, fieldErrorPaths(err))
+		})
+	}
+}
+
+func TestHealthDependencies_Kafka(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.Health.Dependencies = []string{"kafka"}
+
+	// Act
+	errMissing := cfg.Validate()
+	cfg.Kafka.Brokers = []string{"kafka-1:9092"}
+	errConfigured := cfg.Validate()
+
+	// Assert
+	require.Error(t, errMissing, "Comprobar kafka sin brokers debería fallar")
+	assert.Contains(t, errMissing.Error(), "kafka")
+	assert.NoError(t, errConfigured)
+}
@@ -0,0 +1,234 @@
+// loader.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// Loader es una carga de configuración independiente del singleton que, además del
+// Config decodificado, conserva la instancia de Viper con todas las fuentes fusionadas.
+// Sirve para consultas que el struct Config no cubre (claves dinámicas, presencia de claves...).
+type Loader struct {
+	opts Options
+
+	// mu protege v, ov, cfg y revision frente a las recargas.
+	mu  sync.RWMutex
+	v   *viper.Viper
+	ov  overrides
+	cfg *Config
+	// revision es la revisión del almacén remoto en la última carga. Ver RemoteRevision().
+	revision string
+}
+
+// NewLoader carga la configuración con las opciones dadas y devuelve un Loader.
+// A diferencia de Init, no toca el singleton global.
+func NewLoader(opts Options) (*Loader, error) {
+	// La revisión se lee antes que el contenido: si cambia entre ambas lecturas, la
+	// siguiente recarga no se omite.
+	revision, err := remoteRevision(opts)
+	if err != nil {
+		return nil, err
+	}
+	ov := overrides{}
+	cfg, v, err := loadWithViper(opts, ov)
+	if err != nil {
+		return nil, err
+	}
+	return &Loader{opts: opts, v: v, ov: ov, cfg: cfg, revision: revision}, nil
+}
+
+// RemoteRevision devuelve la revisión del almacén remoto en la última carga (ver
+// RemoteProvider.Revision), o "" si no hay almacén remoto o no informa de su revisión.
+func (l *Loader) RemoteRevision() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.revision
+}
+
+// Reload vuelve a cargar todas las fuentes con las opciones del Loader y devuelve si
+// lo hizo. Si el almacén remoto informa de su revisión y no ha cambiado desde la
+// última carga, no recarga nada y devuelve false. Si tiene éxito, Config() pasa a
+// devolver un *Config nuevo; si falla, se conserva la configuración previa.
+func (l *Loader) Reload() (bool, error) {
+	revision, err := remoteRevision(l.opts)
+	if err != nil {
+		return false, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if revision != "" && revision == l.revision {
+		return false, nil
+	}
+	ov := overrides{}
+	cfg, v, err := loadWithViper(l.opts, ov)
+	if err != nil {
+		return false, err
+	}
+	l.v, l.ov, l.cfg, l.revision = v, ov, cfg, revision
+	return true, nil
+}
+
+// Config devuelve la configuración cargada por el Loader.
+func (l *Loader) Config() *Config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg
+}
+
+// Viper devuelve la instancia de Viper del Loader, con todas las fuentes fusionadas,
+// para usar funciones que esta librería no expone (Sub, AllSettings, IsSet...).
+// Quien la usa se hace responsable de ella: es la misma instancia que usan ReloadEnv
+// y el resto de métodos, no está protegida por el Loader frente a accesos concurrentes
+// y cualquier cambio (Set, SetDefault...) afecta a las siguientes recargas.
+func (l *Loader) Viper() *viper.Viper {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v
+}
+
+// ReloadEnv vuelve a leer las variables de entorno, incluidos los archivos de
+// Options.DotEnvPaths, y decodifica de nuevo la configuración sin releer el archivo. Si tiene éxito, Config() pasa a devolver un
+// *Config nuevo; el anterior no se modifica. Si falla, se conserva la configuración previa.
+func (l *Loader) ReloadEnv() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := loadDotEnv(l.opts.DotEnvPaths); err != nil {
+		return err
+	}
+	cfg, err := guardedDecode(l.v, l.ov, l.opts)
+	if err != nil {
+		return err
+	}
+	l.cfg = cfg
+	return nil
+}
+
+// MinimalConfig devuelve, como mapa anidado, solo las claves que el archivo, el
+// entorno o los flags definen con un valor distinto de su valor por defecto (de la
+// librería, de Options.Defaults o de Options.DefaultFuncs, que se vuelven a evaluar).
+// Sirve para generar archivos de configuración mínimos. Las claves resueltas desde archivos
+// (*_file) o gestores de secretos nunca incluyen el secreto: llevan el valor que tenían
+// antes de resolverse, como la referencia "esquema:...".
+func (l *Loader) MinimalConfig() map[string]any {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	defaults := newViper()
+	applyDefaults(defaults, l.opts)
+
+	minimal := map[string]any{}
+	for _, key := range l.v.AllKeys() {
+		if !l.v.InConfig(key) && !envDefined(key, l.opts) && !flagChanged(key, l.opts) {
+			continue
+		}
+		value := l.v.Get(key)
+		if override := l.ov[key]; override.reference {
+			if override.original == nil {
+				continue
+			}
+			value = override.original
+		}
+		if defaults.IsSet(key) && fmt.Sprint(defaults.Get(key)) == fmt.Sprint(value) {
+			continue
+		}
+		setNested(minimal, key, value)
+	}
+	return minimal
+}
+
+// RequirePresent comprueba que cada clave (ruta con puntos, ej: "database.host") esté
+// definida en alguna fuente: archivo, entorno o valores por defecto. Devuelve un único
+// error que enumera todas las claves ausentes, o nil si están todas.
+func (l *Loader) RequirePresent(keys ...string) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if missing := missingKeys(l.v, keys); len(missing) > 0 {
+		return fmt.Errorf("configloader: faltan claves obligatorias: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// GetValidDuration lee la clave (ruta con puntos) como duración, aceptando el formato
+// de Go (ej: "1h30m"). Devuelve un error si la clave no está definida, si su valor no
+// es una duración válida o si es negativa. El error nunca incluye el valor leído.
+func (l *Loader) GetValidDuration(key string) (time.Duration, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.v.IsSet(key) {
+		return 0, fmt.Errorf("configloader: la clave %q no está definida", key)
+	}
+	d, err := cast.ToDurationE(l.v.Get(key))
+	if err != nil {
+		return 0, fmt.Errorf("configloader: la clave %q no contiene una duración válida", key)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("configloader: la clave %q contiene una duración negativa", key)
+	}
+	return d, nil
+}
+
+// MustGetDuration es como GetValidDuration, pero entra en pánico si la duración no es válida.
+// Como el error de GetValidDuration, el pánico nombra la clave pero no su valor.
+// Pensada para claves imprescindibles durante el arranque.
+func (l *Loader) MustGetDuration(key string) time.Duration {
+	d, err := l.GetValidDuration(key)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// unprovidedKeys devuelve, en orden, las claves de opts.RequiredKeys que no vienen de
+// ningún archivo (ni de su variante *_file), ni del entorno ni de un flag indicado por
+// el usuario. No usa IsSet porque cuenta
+// los valores por defecto, que se registran en v al decodificar. Las claves que solo
+// están en el entorno se registran para que se decodifiquen.
+func unprovidedKeys(v *viper.Viper, opts Options) []string {
+	var missing []string
+	for _, key := range opts.RequiredKeys {
+		switch {
+		case v.InConfig(key), v.InConfig(key + fileKeySuffix), flagChanged(key, opts):
+		case envDefined(key, opts):
+			_ = v.BindEnv(key)
+		default:
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// missingKeys devuelve, en el orden recibido, las claves que no están definidas en v.
+func missingKeys(v *viper.Viper, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if !v.IsSet(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// checkMutuallyExclusive devuelve un error por cada grupo en el que hay más de una
+// clave definida en v, agrupados en uno solo; nil si ningún grupo tiene conflictos.
+func checkMutuallyExclusive(v *viper.Viper, groups [][]string) error {
+	var errs []error
+	for _, group := range groups {
+		var set []string
+		for _, key := range group {
+			if v.IsSet(key) {
+				set = append(set, key)
+			}
+		}
+		if len(set) > 1 {
+			errs = append(errs, fmt.Errorf("configloader: claves mutuamente excluyentes definidas a la vez: %s", strings.Join(set, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,251 @@
+// loader_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_RequirePresent(t *testing.T) {
+	// Arrange: una clave en el archivo, otra en el entorno y otra que no existe.
+	tempDir := writeTempConfig(t, "present.yaml", "database:\n  host: \"db-host\"\nfeatures:\n  beta: true\n")
+	t.Setenv("MYAPP_DATABASE_USER", "filingo")
+	loader, err := NewLoader(Options{
+		ConfigName:  "present",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+	})
+	require.NoError(t, err)
+
+	// Act & Assert: las claves presentes, incluso fuera del struct, pasan la comprobación.
+	assert.Equal(t, "db-host", loader.Config().DB.Host)
+	assert.NoError(t, loader.RequirePresent("database.host", "database.user", "features.beta"))
+
+	// Act & Assert: el error enumera todas las claves ausentes.
+	err = loader.RequirePresent("database.host", "redis.address", "features.gamma")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redis.address")
+	assert.Contains(t, err.Error(), "features.gamma")
+	assert.NotContains(t, err.Error(), "database.host")
+}
+
+func TestLoad_MutuallyExclusive(t *testing.T) {
+	// Arrange: se configuran a la vez Redis y una caché en memoria.
+	yamlContent := `
+redis:
+  address: "localhost:6379"
+cache:
+  memory:
+    size: 100
+database:
+  host: "db-host"
+`
+	tempDir := writeTempConfig(t, "exclusive.yaml", yamlContent)
+	opts := Options{
+		ConfigName:  "exclusive",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		MutuallyExclusive: [][]string{
+			{"redis", "cache.memory"},
+			{"database.host", "database.url"},
+		},
+	}
+
+	// Act
+	_, err := load(opts)
+
+	// Assert: solo el grupo en conflicto se reporta.
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redis, cache.memory")
+	assert.NotContains(t, err.Error(), "database.host")
+
+	// Sin el conflicto la carga funciona.
+	opts.MutuallyExclusive = opts.MutuallyExclusive[1:]
+	_, err = load(opts)
+	assert.NoError(t, err)
+}
+
+func TestLoader_ReloadEnv(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "reload.yaml", "database:\n  host: \"db-file\"\n  max_connections: 10\n")
+	t.Setenv("MYAPP_DATABASE_HOST", "db-env-1")
+	loader, err := NewLoader(Options{ConfigName: "reload", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+	require.NoError(t, err)
+	before := loader.Config()
+	require.Equal(t, "db-env-1", before.DB.Host)
+
+	// Act: cambia el entorno y se recarga.
+	t.Setenv("MYAPP_DATABASE_HOST", "db-env-2")
+	require.NoError(t, loader.ReloadEnv())
+
+	// Assert: la nueva instancia refleja el cambio y la anterior queda intacta.
+	after := loader.Config()
+	assert.Equal(t, "db-env-2", after.DB.Host)
+	assert.Equal(t, int32(10), after.DB.MaxConns)
+	assert.Equal(t, "db-env-1", before.DB.Host)
+}
+
+func TestLoader_GetValidDuration(t *testing.T) {
+	// Arrange: claves dinámicas que no forman parte del struct Config.
+	yamlContent := `
+jobs:
+  cleanup_interval: "15m"
+  negative_interval: "-5s"
+  broken_interval: "pronto"
+`
+	tempDir := writeTempConfig(t, "durations.yaml", yamlContent)
+	loader, err := NewLoader(Options{ConfigName: "durations", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+	require.NoError(t, err)
+
+	// Duración válida.
+	d, err := loader.GetValidDuration("jobs.cleanup_interval")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, d)
+	assert.Equal(t, 15*time.Minute, loader.MustGetDuration("jobs.cleanup_interval"))
+
+	// Duración negativa.
+	_, err = loader.GetValidDuration("jobs.negative_interval")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "negativa")
+	assert.Panics(t, func() { loader.MustGetDuration("jobs.negative_interval") })
+
+	// Valor que no es una duración; el error no revela el valor.
+	_, err = loader.GetValidDuration("jobs.broken_interval")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "pronto")
+	assert.Panics(t, func() { loader.MustGetDuration("jobs.broken_interval") })
+
+	// Clave inexistente.
+	_, err = loader.GetValidDuration("jobs.missing_interval")
+	assert.Error(t, err)
+}
+
+func TestLoad_RequiredKeys(t *testing.T) {
+	// Arrange: client_id está vacío a propósito; client_secret no aparece en ninguna fuente.
+	yamlContent := `
+google_oauth2:
+  client_id: ""
+`
+	tempDir := writeTempConfig(t, "required.yaml", yamlContent)
+	t.Setenv("MYAPP_REDIS_ADDRESS", "localhost:6379")
+	opts := Options{
+		ConfigName:   "required",
+		ConfigType:   "yaml",
+		ConfigPaths:  []string{tempDir},
+		EnvPrefix:    "MYAPP",
+		Defaults:     map[string]any{"database.host": "db.local"},
+		RequiredKeys: []string{"google_oauth2.client_id", "google_oauth2.client_secret", "redis.address", "database.host"},
+	}
+
+	// Act
+	_, err := load(opts)
+	opts.RequiredKeys = []string{"google_oauth2.client_id", "redis.address"}
+	cfg, errPresent := load(opts)
+
+	// Assert: se enumeran todas las ausentes; un valor por defecto no cuenta.
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "google_oauth2.client_secret, database.host")
+	assert.NotContains(t, err.Error(), "client_id")
+	require.NoError(t, errPresent)
+	assert.Equal(t, "localhost:6379", cfg.Redis.Address, "Una clave requerida del entorno debería decodificarse")
+}
+
+func TestLoad_RequiredKeyFromFlag(t *testing.T) {
+	// Arrange: solo un flag indicado por el usuario define la clave obligatoria.
+	fs := pflag.NewFlagSet("filingo", pflag.ContinueOnError)
+	fs.String("db-host", "", "host de la base de datos")
+	require.NoError(t, fs.Parse([]string{"--db-host=db-flag"}))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:   "no-existe",
+		FlagSet:      fs,
+		FlagKeys:     map[string]string{"db-host": "database.host"},
+		RequiredKeys: []string{"database.host"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db-flag", cfg.DB.Host)
+}
+
+func TestLoader_MinimalConfig(t *testing.T) {
+	// Arrange: el archivo repite dos valores por defecto y cambia otros.
+	yamlContent := `
+application:
+  name: "filingo"
+database:
+  port: 5432
+  host: "db-file"
+migrations:
+  table: "schema_migrations"
+recovery:
+  enabled: false
+`
+	tempDir := writeTempConfig(t, "minimal.yaml", yamlContent)
+	t.Setenv("MYAPP_DATABASE_HOST", "db-env")
+	loader, err := NewLoader(Options{
+		ConfigName:  "minimal",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		Defaults:    map[string]any{"database.port": 5432, "database.name": "filingo"},
+	})
+	require.NoError(t, err)
+
+	// Act
+	minimal := loader.MinimalConfig()
+
+	// Assert: solo quedan los valores distintos de su valor por defecto.
+	assert.Equal(t, map[string]any{
+		"application": map[string]any{"name": "filingo"},
+		"database":    map[string]any{"host": "db-env"},
+		"recovery":    map[string]any{"enabled": false},
+	}, minimal)
+}
+
+func TestLoader_MinimalConfigKeepsReferences(t *testing.T) {
+	// Arrange: un secreto por referencia y otro por archivo *_file.
+	secretPath := filepath.Join(t.TempDir(), "redis_pass")
+	require.NoError(t, os.WriteFile(secretPath, []byte("REDISSECRET"), 0600))
+	yamlContent := "database:\n  password: \"mem:db\"\nredis:\n  password_file: \"" + secretPath + "\"\n"
+	tempDir := writeTempConfig(t, "refs.yaml", yamlContent)
+	loader, err := NewLoader(Options{
+		ConfigName:      "refs",
+		ConfigType:      "yaml",
+		ConfigPaths:     []string{tempDir},
+		SecretProviders: map[string]SecretProvider{"mem": MemorySecretProvider{"db": "TOPSECRET"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "TOPSECRET", loader.Config().DB.Password)
+
+	// Act
+	minimal := loader.MinimalConfig()
+
+	// Assert: se conservan las referencias, nunca los secretos resueltos.
+	assert.Equal(t, map[string]any{
+		"database": map[string]any{"password": "mem:db"},
+		"redis":    map[string]any{"password_file": secretPath},
+	}, minimal)
+}
+
+func TestLoader_Viper(t *testing.T) {
+	// Arrange: una sección que Config no recoge.
+	tempDir := writeTempConfig(t, "viper.yaml", "database:\n  host: \"db-file\"\nfeatures:\n  beta: true\n")
+	loader, err := NewLoader(Options{ConfigName: "viper", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+	require.NoError(t, err)
+
+	// Act
+	v := loader.Viper()
+
+	// Assert
+	assert.True(t, v.IsSet("features.beta"))
+	assert.Equal(t, "db-file", v.Sub("database").GetString("host"))
+}
@@ -0,0 +1,23 @@
+// maintenance.go
+
+package configloader
+
+import "time"
+
+// MaintenanceConfig es el interruptor de modo mantenimiento. Junto con la recarga
+// de configuración permite activarlo en caliente para que el middleware responda
+// 503 con Message y la cabecera Retry-After.
+type MaintenanceConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	Message    string        `mapstructure:"message"`
+	RetryAfter time.Duration `mapstructure:"retry_after" min:"0"`
+}
+
+// RetryAfterSeconds devuelve RetryAfter en segundos enteros, redondeando hacia
+// arriba, listo para la cabecera HTTP Retry-After. Devuelve 0 si no es positivo.
+func (m *MaintenanceConfig) RetryAfterSeconds() int {
+	if m.RetryAfter <= 0 {
+		return 0
+	}
+	return int((m.RetryAfter + time.Second - 1) / time.Second)
+}
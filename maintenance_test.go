@@ -0,0 +1,43 @@
+// maintenance_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Maintenance(t *testing.T) {
+	// Arrange
+	yamlContent := `
+maintenance:
+  enabled: true
+  message: "En mantenimiento"
+  retry_after: "2m"
+`
+	tempDir := writeTempConfig(t, "maintenance.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "maintenance", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cfg.Maintenance.Enabled)
+	assert.Equal(t, "En mantenimiento", cfg.Maintenance.Message)
+	assert.Equal(t, 120, cfg.Maintenance.RetryAfterSeconds())
+}
+
+func TestMaintenance_RetryAfterSeconds(t *testing.T) {
+	tests := map[time.Duration]int{
+		0:                       0,
+		-time.Second:            0,
+		1500 * time.Millisecond: 2, // Se redondea hacia arriba.
+		30 * time.Second:        30,
+	}
+	for retryAfter, expected := range tests {
+		m := MaintenanceConfig{RetryAfter: retryAfter}
+		assert.Equal(t, expected, m.RetryAfterSeconds(), "retry_after %s", retryAfter)
+	}
+}
@@ -0,0 +1,58 @@
+// merge.go
+
+package configloader
+
+import "reflect"
+
+// Merge devuelve una configuración nueva con base como punto de partida y los valores
+// de override por encima (ej: los valores por defecto de un test más las sustituciones
+// de cada caso). Ni base ni override se modifican; si uno es nil, el resultado es una
+// copia del otro.
+//
+// Un campo de override sustituye al de base solo si no tiene su valor cero, así que no
+// se puede fijar un campo a 0, "" o false mediante Merge: en ese caso hay que asignarlo
+// en el resultado. Los structs se combinan campo a campo y los mapas clave a clave (gana
+// la entrada de override); los slices y punteros no nulos de override sustituyen a los
+// de base completos. Los datos de la carga (Warnings, SourceFile, AllSettings, las
+// fuentes de FlatList y las secciones de plugins) son los de base.
+func Merge(base, override *Config) *Config {
+	switch {
+	case base == nil && override == nil:
+		return &Config{}
+	case base == nil:
+		return override.Clone()
+	case override == nil:
+		return base.Clone()
+	}
+	merged := base.Clone()
+	mergeValue(reflect.ValueOf(merged).Elem(), reflect.ValueOf(override).Elem())
+	return merged
+}
+
+// mergeValue copia en dst los valores no cero de src, recorriendo los structs campo a
+// campo (solo los exportados) y los mapas clave a clave. dst debe ser asignable.
+func mergeValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(deepCopy(src))
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// merge_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	// Arrange
+	base := validConfig()
+	base.HTTP.AllowedOrigins = []string{"https://base.com"}
+	base.RateLimit.Routes = map[string]RouteLimit{"/api": {RequestsPerSecond: 1}, "/login": {Burst: 3}}
+	base.file = "/etc/filingo/config.yaml"
+	override := &Config{
+		DB:        DBConfig{Host: "db.test", MaxConnLifeTime: time.Minute},
+		HTTP:      HTTPConfig{AllowedOrigins: []string{"https://test.com"}},
+		RateLimit: RateLimitConfig{Routes: map[string]RouteLimit{"/api": {Burst: 9}}},
+	}
+
+	// Act
+	merged := Merge(base, override)
+
+	// Assert
+	assert.Equal(t, "db.test", merged.DB.Host)
+	assert.Equal(t, time.Minute, merged.DB.MaxConnLifeTime)
+	assert.Equal(t, int32(5432), merged.DB.Port, "Los valores cero de override no sustituyen a base")
+	assert.Equal(t, "filingo", merged.App.Name)
+	assert.Equal(t, []string{"https://test.com"}, merged.HTTP.AllowedOrigins)
+	assert.Equal(t, map[string]RouteLimit{"/api": {Burst: 9}, "/login": {Burst: 3}}, merged.RateLimit.Routes)
+	assert.Equal(t, "/etc/filingo/config.yaml", merged.SourceFile())
+}
+
+func TestMerge_DoesNotModifyInputs(t *testing.T) {
+	// Arrange
+	base := validConfig()
+	base.RateLimit.Routes = map[string]RouteLimit{"/api": {RequestsPerSecond: 1}}
+	override := &Config{RateLimit: RateLimitConfig{Routes: map[string]RouteLimit{"/admin": {Burst: 1}}}}
+
+	// Act
+	merged := Merge(base, override)
+	merged.HTTP.AllowedOrigins = append(merged.HTTP.AllowedOrigins, "https://x.com")
+
+	// Assert
+	assert.Len(t, base.RateLimit.Routes, 1)
+	assert.Len(t, merged.RateLimit.Routes, 2)
+	assert.Empty(t, base.HTTP.AllowedOrigins)
+}
+
+func TestMerge_Nil(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+
+	// Act & Assert
+	assert.Equal(t, cfg, Merge(cfg, nil))
+	assert.Equal(t, cfg, Merge(nil, cfg))
+	assert.NotSame(t, cfg, Merge(cfg, nil))
+	assert.Equal(t, &Config{}, Merge(nil, nil))
+}
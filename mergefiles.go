@@ -0,0 +1,83 @@
+// mergefiles.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// mergeFiles fusiona en v, en orden, los archivos de opts.MergeFiles, de modo que cada
+// uno sustituye las claves de los anteriores y del archivo principal. Los archivos que
+// no existen se ignoran, igual que el archivo principal.
+func mergeFiles(v *viper.Viper, opts Options) error {
+	for _, entry := range opts.MergeFiles {
+		path, ok := findMergeFile(entry, opts)
+		if !ok {
+			continue
+		}
+		settings, err := readFileSettings(path, mergeFileType(path, opts.ConfigType))
+		if errors.Is(err, ErrConfigParse) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("error al leer el archivo de configuración %q: %w", path, err)
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			return fmt.Errorf("error al fusionar el archivo de configuración %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// findMergeFile localiza una entrada de MergeFiles. Una ruta con directorio (ej:
+// "/etc/filingo/config.local.yaml") se usa tal cual; un nombre de archivo se busca en
+// las rutas de búsqueda, tal cual si tiene una extensión admitida (ej:
+// "config.local.yaml") o, si no (ej: "config.local"), con cada extensión admitida.
+func findMergeFile(entry string, opts Options) (string, bool) {
+	if strings.ContainsRune(entry, filepath.Separator) {
+		return entry, fileExists(entry)
+	}
+	var names []string
+	if IsSupportedConfigType(strings.TrimPrefix(filepath.Ext(entry), ".")) {
+		names = []string{entry}
+	} else {
+		for _, ext := range SupportedConfigTypes {
+			names = append(names, entry+"."+ext)
+		}
+	}
+	for _, dir := range searchPaths(opts) {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if fileExists(path) {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// mergeFileType deduce el formato de un archivo por su extensión, para poder mezclar
+// formatos (ej: una base YAML con retoques en JSON); si no la tiene usa configType.
+func mergeFileType(path, configType string) string {
+	if ext := strings.TrimPrefix(filepath.Ext(path), "."); IsSupportedConfigType(ext) {
+		return ext
+	}
+	return configType
+}
+
+// fileExists indica si path existe y es un archivo. Un error distinto de "no existe"
+// (ej: permiso denegado) cuenta como existente, para que al leerlo se informe del error.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return !errors.Is(err, fs.ErrNotExist)
+	}
+	return info.Mode().IsRegular()
+}
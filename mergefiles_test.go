@@ -0,0 +1,63 @@
+// mergefiles_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MergeFiles(t *testing.T) {
+	// Arrange: una base YAML, retoques locales en JSON y un archivo que no existe.
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"principal\"\n  port: 8080\n")
+	base := "database:\n  host: \"db.base\"\n  port: 5432\napplication:\n  name: \"base\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.base.yaml"), []byte(base), 0o644))
+	local := `{"database": {"host": "localhost"}}`
+	localPath := filepath.Join(tempDir, "config.local.json")
+	require.NoError(t, os.WriteFile(localPath, []byte(local), 0o644))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:  "config",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		MergeFiles:  []string{"config.base", localPath, "config.inexistente"},
+	})
+
+	// Assert: cada archivo gana a los anteriores, incluido el principal.
+	require.NoError(t, err)
+	assert.Equal(t, "base", cfg.App.Name)
+	assert.Equal(t, int32(8080), cfg.App.Port, "Las claves no redefinidas se conservan")
+	assert.Equal(t, "localhost", cfg.DB.Host)
+	assert.Equal(t, int32(5432), cfg.DB.Port)
+}
+
+func TestLoad_MergeFilesNameWithExtension(t *testing.T) {
+	// Arrange: el nombre lleva extensión pero no directorio, y el proceso no corre en tempDir.
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"principal\"\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.local.yaml"), []byte("application:\n  name: \"local\"\n"), 0o644))
+
+	// Act
+	cfg, err := load(Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}, MergeFiles: []string{"config.local.yaml"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "local", cfg.App.Name, "El archivo debería buscarse en ConfigPaths")
+}
+
+func TestLoad_MergeFilesInvalid(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "config.yaml", "application:\n  name: \"principal\"\n")
+	broken := filepath.Join(tempDir, "broken.yaml")
+	require.NoError(t, os.WriteFile(broken, []byte("application: [roto\n"), 0o644))
+
+	// Act
+	_, err := load(Options{ConfigName: "config", ConfigType: "yaml", ConfigPaths: []string{tempDir}, MergeFiles: []string{broken}})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), broken)
+}
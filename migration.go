@@ -0,0 +1,35 @@
+// migration.go
+
+package configloader
+
+import (
+	"fmt"
+	"os"
+)
+
+// MigrationConfig contiene la configuración del ejecutor de migraciones de la base
+// de datos definida en la sección DB.
+type MigrationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Path es el directorio que contiene los archivos de migración.
+	Path string `mapstructure:"path" requiredif:"Enabled=true"`
+	// Table es la tabla donde se registra la versión del esquema (por defecto "schema_migrations").
+	Table       string `mapstructure:"table"`
+	AutoMigrate bool   `mapstructure:"auto_migrate"`
+}
+
+// validate comprueba que, con las migraciones activas, Path sea un directorio existente.
+// Que Path no esté vacío lo comprueba su tag `requiredif`.
+func (m MigrationConfig) validate() []error {
+	if !m.Enabled || m.Path == "" {
+		return nil
+	}
+	info, err := os.Stat(m.Path)
+	if err != nil {
+		return []error{&FieldError{Path: "migrations.path", Message: fmt.Sprintf("el directorio %q no existe o no es accesible", m.Path)}}
+	}
+	if !info.IsDir() {
+		return []error{&FieldError{Path: "migrations.path", Message: fmt.Sprintf("%q no es un directorio", m.Path)}}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// migration_test.go
+package configloader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MigrationsDefaultTable(t *testing.T) {
+	// Arrange
+	yamlContent := `
+migrations:
+  enabled: true
+  auto_migrate: true
+`
+	tempDir := writeTempConfig(t, "migrations.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "migrations", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cfg.Migrations.AutoMigrate)
+	assert.Equal(t, "schema_migrations", cfg.Migrations.Table, "Debería aplicarse la tabla por defecto")
+}
+
+func TestValidate_MigrationsPath(t *testing.T) {
+	existingDir := t.TempDir()
+	tests := map[string]struct {
+		migrations MigrationConfig
+		wantError  bool
+	}{
+		"desactivadas sin ruta":  {MigrationConfig{}, false},
+		"directorio existente":   {MigrationConfig{Enabled: true, Path: existingDir}, false},
+		"sin ruta":               {MigrationConfig{Enabled: true}, true},
+		"directorio inexistente": {MigrationConfig{Enabled: true, Path: filepath.Join(existingDir, "nope")}, true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			cfg.Migrations = tc.migrations
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			if tc.wantError {
+				assert.Equal(t, []string{"migrations.path"}, fieldErrorPaths(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
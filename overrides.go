@@ -0,0 +1,46 @@
+// overrides.go
+
+package configloader
+
+import "github.com/spf13/viper"
+
+// overrides registra las claves que prepare fija en Viper con Set. Un Set tiene
+// prioridad sobre todas las fuentes y se queda en la instancia, así que hay que
+// deshacerlo antes de volver a decodificar: si no, Loader.ReloadEnv seguiría viendo los
+// valores de la carga anterior aunque el entorno haya cambiado.
+type overrides map[string]override
+
+// override es un valor fijado por prepare.
+type override struct {
+	// reference indica que el valor se resolvió desde una referencia (una clave *_file
+	// o un secreto), y original es el valor que tenía la clave antes de resolverla.
+	reference bool
+	original  any
+}
+
+// set fija value como valor de key en v y registra la clave.
+func (o overrides) set(v *viper.Viper, key string, value any) {
+	if _, ok := o[key]; !ok {
+		o[key] = override{}
+	}
+	v.Set(key, value)
+}
+
+// resolve fija value, resuelto desde una referencia, como valor de key en v y guarda
+// el valor que tenía la clave. Si ya se había resuelto (ej: un archivo *_file que
+// contiene una referencia a un secreto), se conserva el primer valor.
+func (o overrides) resolve(v *viper.Viper, key string, value any) {
+	if !o[key].reference {
+		o[key] = override{reference: true, original: v.Get(key)}
+	}
+	v.Set(key, value)
+}
+
+// reset deshace en v los valores registrados. Con un Set a nil, Viper vuelve a
+// consultar el resto de fuentes (flags, entorno, archivo y valores por defecto).
+func (o overrides) reset(v *viper.Viper) {
+	for key := range o {
+		v.Set(key, nil)
+		delete(o, key)
+	}
+}
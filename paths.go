@@ -0,0 +1,58 @@
+// paths.go
+
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+)
+
+// searchPaths devuelve las rutas donde buscar el archivo de configuración:
+// primero opts.ConfigPaths y, si opts.UseStandardPaths está activo, las rutas
+// estándar del sistema operativo para opts.AppName.
+func searchPaths(opts Options) []string {
+	if !opts.UseStandardPaths {
+		return opts.ConfigPaths
+	}
+	return append(slices.Clone(opts.ConfigPaths), standardPaths(runtime.GOOS, opts.AppName)...)
+}
+
+// standardPaths devuelve, de la más específica del usuario a la más general del
+// sistema, las rutas de configuración habituales de appName en el sistema goos:
+//   - Linux y demás Unix: $XDG_CONFIG_HOME/app (o ~/.config/app), ~/.app y /etc/app.
+//   - macOS: ~/Library/Application Support/app, $XDG_CONFIG_HOME/app si está definido, ~/.app y /etc/app.
+//   - Windows: %APPDATA%\app, ~\.app y %PROGRAMDATA%\app.
+//
+// Las rutas cuya variable de entorno base no está definida se omiten.
+func standardPaths(goos, appName string) []string {
+	var paths []string
+	add := func(base string, elem ...string) {
+		if base != "" {
+			paths = append(paths, filepath.Join(append([]string{base}, elem...)...))
+		}
+	}
+
+	if goos == "windows" {
+		home := os.Getenv("USERPROFILE")
+		add(os.Getenv("APPDATA"), appName)
+		add(home, "."+appName)
+		add(os.Getenv("PROGRAMDATA"), appName)
+		return paths
+	}
+
+	home := os.Getenv("HOME")
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if goos == "darwin" {
+		add(home, "Library", "Application Support", appName)
+		add(xdg, appName)
+	} else if xdg != "" {
+		add(xdg, appName)
+	} else {
+		add(home, ".config", appName)
+	}
+	add(home, "."+appName)
+	paths = append(paths, filepath.Join("/etc", appName))
+	return paths
+}
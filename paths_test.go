@@ -0,0 +1,75 @@
+// paths_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardPaths_Linux(t *testing.T) {
+	t.Setenv("HOME", "/home/ana")
+	t.Setenv("XDG_CONFIG_HOME", "/home/ana/.xdg")
+
+	assert.Equal(t, []string{
+		"/home/ana/.xdg/filingo",
+		"/home/ana/.filingo",
+		"/etc/filingo",
+	}, standardPaths("linux", "filingo"))
+
+	// Sin XDG_CONFIG_HOME se usa su valor por defecto, ~/.config.
+	t.Setenv("XDG_CONFIG_HOME", "")
+	assert.Equal(t, "/home/ana/.config/filingo", standardPaths("linux", "filingo")[0])
+}
+
+func TestStandardPaths_Darwin(t *testing.T) {
+	t.Setenv("HOME", "/Users/ana")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	assert.Equal(t, []string{
+		"/Users/ana/Library/Application Support/filingo",
+		"/Users/ana/.filingo",
+		"/etc/filingo",
+	}, standardPaths("darwin", "filingo"))
+}
+
+func TestStandardPaths_Windows(t *testing.T) {
+	t.Setenv("USERPROFILE", "/users/ana")
+	t.Setenv("APPDATA", "/users/ana/appdata")
+	t.Setenv("PROGRAMDATA", "/programdata")
+
+	assert.Equal(t, []string{
+		filepath.Join("/users/ana/appdata", "filingo"),
+		filepath.Join("/users/ana", ".filingo"),
+		filepath.Join("/programdata", "filingo"),
+	}, standardPaths("windows", "filingo"))
+}
+
+func TestLoad_UseStandardPaths(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("el test usa la ruta XDG de Linux")
+	}
+
+	// Arrange: el archivo está en el directorio XDG del usuario.
+	xdgHome := t.TempDir()
+	configDir := filepath.Join(xdgHome, "filingo")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("application:\n  name: \"desde-xdg\"\n"), 0644))
+	t.Setenv("XDG_CONFIG_HOME", xdgHome)
+	t.Setenv("HOME", t.TempDir())
+
+	// Act
+	cfg, err := load(Options{ConfigName: "config", ConfigType: "yaml", UseStandardPaths: true, AppName: "filingo"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-xdg", cfg.App.Name)
+
+	// Sin AppName es un error.
+	_, err = load(Options{ConfigName: "config", UseStandardPaths: true})
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+// ports.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// checkPortsAvailable comprueba que los puertos en los que escucha la aplicación
+// (application.port y http.port) se puedan abrir, escuchando en cada uno un instante.
+// Los puertos a 0 se ignoran. Devuelve un *FieldError por cada puerto ocupado.
+func checkPortsAvailable(cfg *Config) error {
+	ports := []struct {
+		path string
+		port int32
+	}{
+		{"application.port", cfg.App.Port},
+		{"http.port", cfg.HTTP.Port},
+	}
+
+	var errs []error
+	checked := map[int32]bool{}
+	for _, p := range ports {
+		if p.port == 0 || checked[p.port] {
+			continue
+		}
+		checked[p.port] = true
+		ln, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(p.port))))
+		if err != nil {
+			errs = append(errs, &FieldError{Path: p.path, Message: fmt.Sprintf("el puerto %d no está disponible: %v", p.port, err)})
+			continue
+		}
+		_ = ln.Close()
+	}
+	return errors.Join(errs...)
+}
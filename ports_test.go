@@ -0,0 +1,42 @@
+// ports_test.go
+package configloader
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_CheckPortsAvailable(t *testing.T) {
+	// Arrange: un listener del test ocupa el puerto configurado para HTTP.
+	held, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = held.Close() })
+	port := held.Addr().(*net.TCPAddr).Port
+	tempDir := writeTempConfig(t, "ports.yaml", fmt.Sprintf("http:\n  port: %d\n", port))
+	opts := Options{ConfigName: "ports", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act
+	_, errDefault := load(opts)
+	opts.CheckPortsAvailable = true
+	_, err = load(opts)
+
+	// Assert
+	require.NoError(t, errDefault, "Sin la opción no se comprueban los puertos")
+	require.Error(t, err)
+	assert.Equal(t, []string{"http.port"}, fieldErrorPaths(err))
+}
+
+func TestCheckPortsAvailable_FreePort(t *testing.T) {
+	// Arrange: se obtiene un puerto libre y se libera.
+	ln, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	port := int32(ln.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, ln.Close())
+
+	// Act & Assert
+	assert.NoError(t, checkPortsAvailable(&Config{App: AppConfig{Port: port}, HTTP: HTTPConfig{Port: port}}))
+}
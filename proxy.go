@@ -0,0 +1,86 @@
+// proxy.go
+
+package configloader
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig contiene el proxy para las conexiones salientes. Los campos vacíos se
+// toman de las variables de entorno estándar (HTTP_PROXY, HTTPS_PROXY y NO_PROXY, o
+// sus versiones en minúsculas).
+type ProxyConfig struct {
+	HTTPProxy  string `mapstructure:"http_proxy"`  // ej: "http://proxy.local:3128"
+	HTTPSProxy string `mapstructure:"https_proxy"` // Proxy para URLs https.
+	// NoProxy es una lista separada por comas de destinos que no usan el proxy:
+	// "*", IPs, rangos CIDR y dominios (que incluyen sus subdominios), con puerto opcional.
+	NoProxy string `mapstructure:"no_proxy"`
+}
+
+// ProxyFunc devuelve una función para http.Transport.Proxy que elige el proxy según
+// el esquema de la URL y excluye los destinos de NoProxy y las direcciones locales,
+// con las mismas reglas que net/http (ver golang.org/x/net/http/httpproxy).
+// Devuelve un proxy nil (conexión directa) cuando no hay ninguno configurado.
+func (p *ProxyConfig) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  firstNonEmpty(p.HTTPProxy, os.Getenv("HTTP_PROXY"), os.Getenv("http_proxy")),
+		HTTPSProxy: firstNonEmpty(p.HTTPSProxy, os.Getenv("HTTPS_PROXY"), os.Getenv("https_proxy")),
+		NoProxy:    firstNonEmpty(p.NoProxy, os.Getenv("NO_PROXY"), os.Getenv("no_proxy")),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// validate comprueba que las URLs de proxy configuradas sean válidas.
+func (p ProxyConfig) validate() []error {
+	var errs []error
+	if _, err := parseProxyURL(p.HTTPProxy); p.HTTPProxy != "" && err != nil {
+		errs = append(errs, &FieldError{Path: "proxy.http_proxy", Message: err.Error()})
+	}
+	if _, err := parseProxyURL(p.HTTPSProxy); p.HTTPSProxy != "" && err != nil {
+		errs = append(errs, &FieldError{Path: "proxy.https_proxy", Message: err.Error()})
+	}
+	return errs
+}
+
+// parseProxyURL interpreta la URL de un proxy; sin esquema se asume "http://".
+func parseProxyURL(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("la URL de proxy %q no es válida", redactProxyURL(proxy))
+	}
+	return u, nil
+}
+
+// redactProxyURL sustituye por "xxxxx" las credenciales (usuario y contraseña) de una
+// URL de proxy con esquema, para mostrarla en un error. No depende de url.Parse,
+// que falla justo con las URL que hay que mostrar: se oculta todo lo que hay entre
+// "://" y la última "@".
+func redactProxyURL(proxy string) string {
+	scheme, rest, _ := strings.Cut(proxy, "://")
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return proxy
+	}
+	return scheme + "://xxxxx" + rest[at:]
+}
+
+// firstNonEmpty devuelve el primer valor no vacío.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+// ratelimit.go
+
+package configloader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteLimit es el límite de peticiones aplicado a una ruta.
+type RouteLimit struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+// RateLimitConfig contiene el límite de peticiones global y los límites por ruta.
+//
+// Las claves de Routes son patrones de ruta (ej: "/api/upload"). Viper normaliza las
+// claves a minúsculas y usa "." como separador, así que los patrones no deben contener puntos.
+type RateLimitConfig struct {
+	Enabled           bool                  `mapstructure:"enabled"`
+	RequestsPerSecond float64               `mapstructure:"requests_per_second" min:"0"`
+	Burst             int                   `mapstructure:"burst" min:"0"`
+	Routes            map[string]RouteLimit `mapstructure:"routes"`
+}
+
+// LimitFor devuelve el límite configurado para la ruta indicada o, si la ruta no
+// tiene uno propio, el límite global.
+func (r *RateLimitConfig) LimitFor(route string) RouteLimit {
+	if limit, ok := r.Routes[route]; ok {
+		return limit
+	}
+	if limit, ok := r.Routes[strings.ToLower(route)]; ok {
+		return limit
+	}
+	return RouteLimit{RequestsPerSecond: r.RequestsPerSecond, Burst: r.Burst}
+}
+
+// validate comprueba que cada ruta tenga un patrón no vacío y valores positivos.
+// Las rutas se recorren ordenadas para que los errores salgan siempre en el mismo orden.
+func (r RateLimitConfig) validate() []error {
+	var errs []error
+	for _, route := range sortedKeys(r.Routes) {
+		limit := r.Routes[route]
+		path := "rate_limit.routes." + route
+		if strings.TrimSpace(route) == "" {
+			errs = append(errs, &FieldError{Path: "rate_limit.routes", Message: "el patrón de ruta no puede estar vacío"})
+			continue
+		}
+		if limit.RequestsPerSecond <= 0 {
+			errs = append(errs, &FieldError{Path: path + ".requests_per_second", Message: fmt.Sprintf("debe ser > 0 (valor: %v)", limit.RequestsPerSecond)})
+		}
+		if limit.Burst <= 0 {
+			errs = append(errs, &FieldError{Path: path + ".burst", Message: fmt.Sprintf("debe ser > 0 (valor: %v)", limit.Burst)})
+		}
+	}
+	return errs
+}
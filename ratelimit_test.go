@@ -0,0 +1,51 @@
+// ratelimit_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_RateLimitRoutes(t *testing.T) {
+	// Arrange
+	yamlContent := `
+rate_limit:
+  enabled: true
+  requests_per_second: 50
+  burst: 100
+  routes:
+    /api/upload:
+      requests_per_second: 2
+      burst: 5
+`
+	tempDir := writeTempConfig(t, "ratelimit.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "ratelimit", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert: la ruta configurada usa su límite y el resto cae al global.
+	require.NoError(t, err)
+	assert.Equal(t, RouteLimit{RequestsPerSecond: 2, Burst: 5}, cfg.RateLimit.LimitFor("/api/upload"))
+	assert.Equal(t, RouteLimit{RequestsPerSecond: 50, Burst: 100}, cfg.RateLimit.LimitFor("/api/users"))
+	assert.Empty(t, cfg.RateLimit.validate())
+}
+
+func TestValidate_RateLimitRoutes(t *testing.T) {
+	cfg := validConfig()
+	cfg.RateLimit.Routes = map[string]RouteLimit{
+		"/api/upload": {RequestsPerSecond: 0, Burst: 5},
+		"/api/search": {RequestsPerSecond: 10, Burst: -1},
+		" ":           {RequestsPerSecond: 1, Burst: 1},
+	}
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"rate_limit.routes",
+		"rate_limit.routes./api/search.burst",
+		"rate_limit.routes./api/upload.requests_per_second",
+	}, fieldErrorPaths(err), "Los errores deberían salir ordenados por ruta")
+}
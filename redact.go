@@ -0,0 +1,152 @@
+// redact.go
+
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// redactedError es un error cuyo mensaje se ha limpiado de secretos. Unwrap devuelve
+// el error original para que errors.Is y errors.As sigan funcionando; su mensaje, en
+// cambio, no está limpio y no debería mostrarse.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// guardedDecode ejecuta decode y, si opts.RedactErrors está activo, convierte un pánico
+// en error y elimina los secretos del error devuelto.
+func guardedDecode(v *viper.Viper, ov overrides, opts Options) (cfg *Config, err error) {
+	if !opts.RedactErrors {
+		return decode(v, ov, opts)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			cfg, err = nil, fmt.Errorf("pánico al cargar la configuración: %v", r)
+		}
+		if err != nil {
+			err = redactSecrets(err, v, opts)
+		}
+	}()
+	return decode(v, ov, opts)
+}
+
+// redactSecrets sustituye en el mensaje de err cada valor de un campo secreto (los
+// marcados con `sensitive:"true"`), tal como llega del entorno o de v, por maskedValue.
+// v puede ser nil si la carga falló antes de leer el archivo.
+func redactSecrets(err error, v *viper.Viper, opts Options) error {
+	msg := err.Error()
+	redacted := msg
+	for _, secret := range secretValues(v, opts) {
+		redacted = strings.ReplaceAll(redacted, secret, maskedValue)
+	}
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+// secretValues devuelve los valores no vacíos de los campos secretos, de más largo a
+// más corto para que un secreto contenido en otro no deje restos al sustituirlo.
+func secretValues(v *viper.Viper, opts Options) []string {
+	seen := map[string]bool{"": true}
+	var secrets []string
+	add := func(values ...string) {
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	walkFields(reflect.ValueOf(Config{}), "", func(path string, field reflect.StructField, _ reflect.Value) {
+		if !isSensitive(field) {
+			return
+		}
+		if value, ok := os.LookupEnv(envVarName(path, opts)); ok {
+			add(value)
+		}
+		if value, ok := os.LookupEnv(field.Tag.Get("env")); ok {
+			add(value)
+		}
+		if v != nil {
+			add(stringLeaves(v.Get(path))...)
+		}
+	})
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+// stringLeaves devuelve como texto los valores escalares de value, recorriendo listas
+// y mapas: un secreto mal indentado puede haber acabado dentro de uno de ellos.
+func stringLeaves(value any) []string {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		var out []string
+		for _, item := range value {
+			out = append(out, stringLeaves(item)...)
+		}
+		return out
+	case map[string]any:
+		var out []string
+		for _, item := range value {
+			out = append(out, stringLeaves(item)...)
+		}
+		return out
+	default:
+		return []string{cast.ToString(value)}
+	}
+}
+
+// Redacted devuelve una copia profunda de c (ver Clone) en la que el valor de todos los
+// campos de texto `sensitive:"true"` o incluidos en Options.RedactKeys, también los de
+// las secciones de plugins y los de AllSettings, se sustituye por "********". El resto de
+// campos se conservan tal cual y c no se modifica. Pensada para mostrar la configuración
+// efectiva en un endpoint de depuración.
+func (c *Config) Redacted() *Config {
+	cp := c.Clone()
+	maskSecrets(reflect.ValueOf(cp).Elem(), "", c.redactsKey)
+	maskSettingsSecrets(cp.settings)
+	for _, key := range c.redactKeys {
+		maskSettingsKey(cp.settings, key)
+	}
+	for name, section := range cp.sections {
+		if rv := reflect.ValueOf(section); rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+			maskSecrets(rv.Elem(), name, c.redactsKey)
+		}
+	}
+	return cp
+}
+
+// redactsKey indica si path está en Options.RedactKeys o dentro de una sección que lo está.
+func (c *Config) redactsKey(path string) bool {
+	for _, key := range c.redactKeys {
+		if path == key || strings.HasPrefix(path, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecrets sustituye en el struct v, que debe ser asignable y estar en la ruta
+// prefix, el valor de los campos de texto secretos o para los que redacts devuelve
+// true por maskedValue.
+func maskSecrets(v reflect.Value, prefix string, redacts func(path string) bool) {
+	walkFields(v, prefix, func(path string, field reflect.StructField, value reflect.Value) {
+		if (isSensitive(field) || redacts(path)) && value.Kind() == reflect.String {
+			value.SetString(maskedValue)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+// redact_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_RedactErrors(t *testing.T) {
+	// Arrange: la contraseña, mal indentada, queda como lista y no se puede decodificar.
+	yamlContent := `
+database:
+  password:
+    - "s3cr3t-pass"
+redis:
+  password: "otra-clave"
+`
+	tempDir := writeTempConfig(t, "leaky.yaml", yamlContent)
+	opts := Options{ConfigName: "leaky", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act
+	_, plainErr := load(opts)
+	opts.RedactErrors = true
+	_, redactedErr := load(opts)
+
+	// Assert
+	require.Error(t, plainErr)
+	assert.Contains(t, plainErr.Error(), "s3cr3t-pass", "Sin la opción el error repite el secreto")
+	require.Error(t, redactedErr)
+	assert.NotContains(t, redactedErr.Error(), "s3cr3t-pass")
+	assert.Contains(t, redactedErr.Error(), maskedValue)
+	assert.Contains(t, redactedErr.Error(), "database.password", "El error debería seguir indicando la clave")
+}
+
+func TestLoad_RedactErrorsRecoversPanics(t *testing.T) {
+	// Arrange: un validador propio que entra en pánico mostrando un secreto.
+	tempDir := writeTempConfig(t, "panic.yaml", "database:\n  password: \"s3cr3t-pass\"\n")
+	opts := Options{
+		ConfigName:   "panic",
+		ConfigType:   "yaml",
+		ConfigPaths:  []string{tempDir},
+		RedactErrors: true,
+		CustomValidators: []func(*Config) error{
+			func(c *Config) error { panic("contraseña rechazada: " + c.DB.Password) },
+		},
+	}
+
+	// Act
+	_, err := load(opts)
+
+	// Assert
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t-pass")
+	assert.Contains(t, err.Error(), "pánico al cargar la configuración")
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	// Arrange
+	type paymentsConfig struct {
+		APIKey   string `mapstructure:"api_key" sensitive:"true"`
+		Endpoint string `mapstructure:"endpoint"`
+	}
+	cfg := validConfig()
+	cfg.DB.Password = "db-secreto"
+	cfg.OAuth2 = OAuthConfig{GoogleClientID: "client-id", GoogleClientSecret: "oauth-secreto"}
+	cfg.RateLimit.Routes = map[string]RouteLimit{"/api": {RequestsPerSecond: 1, Burst: 2}}
+	cfg.sections = map[string]any{"payments": &paymentsConfig{APIKey: "pk-secreto", Endpoint: "https://pay.example"}}
+	original := cfg.Clone()
+
+	// Act
+	redacted := cfg.Redacted()
+
+	// Assert: los secretos se enmascaran en la copia y el resto se conserva igual.
+	assert.Equal(t, maskedValue, redacted.DB.Password)
+	assert.Equal(t, maskedValue, redacted.OAuth2.GoogleClientSecret)
+	assert.Equal(t, maskedValue, redacted.Redis.Password, "Un secreto vacío también se enmascara")
+	assert.Equal(t, "client-id", redacted.OAuth2.GoogleClientID)
+	assert.Equal(t, cfg.RateLimit, redacted.RateLimit)
+	payments, ok := redacted.Section("payments")
+	require.True(t, ok)
+	assert.Equal(t, &paymentsConfig{APIKey: maskedValue, Endpoint: "https://pay.example"}, payments)
+
+	// El original no se modifica, tampoco a través de los punteros compartidos.
+	assert.Equal(t, original, cfg)
+	redacted.RateLimit.Routes["/api"] = RouteLimit{}
+	assert.Equal(t, RouteLimit{RequestsPerSecond: 1, Burst: 2}, cfg.RateLimit.Routes["/api"])
+}
+
+func TestConfig_RedactedRedactKeys(t *testing.T) {
+	// Arrange: una clave dinámica que no es campo de Config y un campo normal.
+	yamlContent := `
+database:
+  host: "db.interno"
+payments:
+  webhook_token: "whk-secreto"
+  endpoint: "https://pay.example"
+`
+	tempDir := writeTempConfig(t, "redactkeys.yaml", yamlContent)
+	cfg, err := load(Options{
+		ConfigName:  "redactkeys",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		RedactKeys:  []string{"Payments.Webhook_Token", "database.host"},
+	})
+	require.NoError(t, err)
+
+	// Act
+	redacted := cfg.Redacted()
+	data, err := cfg.MarshalJSONRedacted()
+
+	// Assert
+	require.NoError(t, err)
+	payments := redacted.AllSettings()["payments"].(map[string]any)
+	assert.Equal(t, maskedValue, payments["webhook_token"])
+	assert.Equal(t, "https://pay.example", payments["endpoint"])
+	assert.Equal(t, maskedValue, redacted.DB.Host)
+	assert.Equal(t, maskedValue, redacted.AllSettings()["database"].(map[string]any)["host"])
+	assert.NotContains(t, string(data), "db.interno")
+	assert.Contains(t, string(data), `"host":"`+maskedValue+`"`)
+
+	// El original no se modifica.
+	assert.Equal(t, "db.interno", cfg.DB.Host)
+	assert.Equal(t, "whk-secreto", cfg.AllSettings()["payments"].(map[string]any)["webhook_token"])
+}
+
+func TestMaskSettingsKey_Section(t *testing.T) {
+	settings := map[string]any{"payments": map[string]any{"token": "a", "keys": map[string]any{"b": "c"}}, "port": 80}
+
+	maskSettingsKey(settings, "payments")
+	maskSettingsKey(settings, "missing.key")
+
+	assert.Equal(t, map[string]any{
+		"payments": map[string]any{"token": maskedValue, "keys": map[string]any{"b": maskedValue}},
+		"port":     80,
+	}, settings)
+}
@@ -0,0 +1,29 @@
+// redis.go
+
+package configloader
+
+import "time"
+
+// RedisOptions son las opciones de conexión a Redis, independientes del cliente. Sus
+// campos se llaman igual que los de redis.Options de go-redis, así que el adaptador es
+// directo (ej: &redis.Options{Addr: o.Addr, Password: o.Password, DB: o.DB, ...}) sin
+// que esta librería dependa del driver.
+type RedisOptions struct {
+	Addr        string
+	Password    string
+	DB          int
+	PoolSize    int
+	DialTimeout time.Duration
+}
+
+// Options devuelve las opciones de conexión de r. Los valores cero (PoolSize,
+// DialTimeout) significan "el valor por defecto del cliente", como en go-redis.
+func (r RedisConfig) Options() RedisOptions {
+	return RedisOptions{
+		Addr:        r.Address,
+		Password:    r.Password,
+		DB:          r.DB,
+		PoolSize:    r.PoolSize,
+		DialTimeout: r.DialTimeout,
+	}
+}
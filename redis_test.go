@@ -0,0 +1,35 @@
+// redis_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_RedisOptions(t *testing.T) {
+	// Arrange
+	content := "redis:\n  address: \"cache:6379\"\n  password: \"s3cr3t\"\n  db: 2\n  pool_size: 20\n  dial_timeout: \"3s\"\n"
+	tempDir := writeTempConfig(t, "redis.yaml", content)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "redis", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, RedisOptions{Addr: "cache:6379", Password: "s3cr3t", DB: 2, PoolSize: 20, DialTimeout: 3 * time.Second}, cfg.Redis.Options())
+}
+
+func TestValidate_RedisNegativeValues(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.Redis = RedisConfig{Address: "cache:6379", DB: -1, PoolSize: -5}
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	assert.ElementsMatch(t, []string{"redis.db", "redis.pool_size"}, fieldErrorPaths(err))
+}
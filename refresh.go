@@ -0,0 +1,44 @@
+// refresh.go
+
+package configloader
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RefreshConfig configura el refresco periódico de datos externos cacheados
+// (ej: JWKS, feature flags). El jitter reparte los refrescos de las distintas
+// instancias en el tiempo para que no coincidan todas contra el mismo servicio.
+type RefreshConfig struct {
+	Interval time.Duration `mapstructure:"interval"`
+	// Jitter es la desviación máxima, hacia arriba o hacia abajo, aplicada a Interval.
+	Jitter time.Duration `mapstructure:"jitter" min:"0"`
+	// FailureBackoff es la espera antes de reintentar tras un refresco fallido.
+	FailureBackoff time.Duration `mapstructure:"failure_backoff" min:"0"`
+}
+
+// NextInterval devuelve la espera hasta el próximo refresco: Interval desplazado
+// un valor aleatorio uniforme en [-Jitter, +Jitter]. Sin jitter devuelve Interval.
+func (r *RefreshConfig) NextInterval() time.Duration {
+	if r.Jitter <= 0 {
+		return r.Interval
+	}
+	return r.Interval - r.Jitter + time.Duration(rand.Int64N(int64(2*r.Jitter)+1))
+}
+
+// validate comprueba que Interval sea positivo y Jitter menor que Interval, de modo
+// que NextInterval nunca devuelva una espera nula o negativa. Si la sección está
+// vacía se considera no configurada.
+func (r RefreshConfig) validate() []error {
+	if r == (RefreshConfig{}) {
+		return nil
+	}
+	if r.Interval <= 0 {
+		return []error{&FieldError{Path: "refresh.interval", Message: "debe ser positivo"}}
+	}
+	if r.Jitter >= r.Interval {
+		return []error{&FieldError{Path: "refresh.jitter", Message: "debe ser menor que interval"}}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// refresh_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Refresh(t *testing.T) {
+	// Arrange
+	yamlContent := `
+refresh:
+  interval: "5m"
+  jitter: "30s"
+  failure_backoff: "10s"
+`
+	tempDir := writeTempConfig(t, "refresh.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "refresh", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.Refresh.Interval)
+	assert.Equal(t, 30*time.Second, cfg.Refresh.Jitter)
+	assert.Equal(t, 10*time.Second, cfg.Refresh.FailureBackoff)
+}
+
+func TestRefresh_NextIntervalWithinJitter(t *testing.T) {
+	// Arrange
+	r := RefreshConfig{Interval: time.Minute, Jitter: 10 * time.Second}
+
+	for i := 0; i < 100; i++ {
+		// Act
+		next := r.NextInterval()
+
+		// Assert
+		assert.GreaterOrEqual(t, next, 50*time.Second)
+		assert.LessOrEqual(t, next, 70*time.Second)
+	}
+	assert.Equal(t, time.Minute, (&RefreshConfig{Interval: time.Minute}).NextInterval())
+}
+
+func TestValidate_Refresh(t *testing.T) {
+	tests := map[string]struct {
+		refresh   RefreshConfig
+		wantPaths []string
+	}{
+		"no configurada":          {RefreshConfig{}, nil},
+		"válida":                  {RefreshConfig{Interval: time.Minute, Jitter: time.Second}, nil},
+		"interval no positivo":    {RefreshConfig{FailureBackoff: time.Second}, []string{"refresh.interval"}},
+		"jitter igual a interval": {RefreshConfig{Interval: time.Minute, Jitter: time.Minute}, []string{"refresh.jitter"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			cfg.Refresh = tc.refresh
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			assert.Equal(t, tc.wantPaths, fieldErrorPaths(err))
+		})
+	}
+}
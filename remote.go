@@ -0,0 +1,68 @@
+// remote.go
+
+package configloader
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// RemoteProvider es un almacén de configuración remoto (etcd, Consul...) que se lee con
+// el soporte remoto de Viper. Para activarlo, el binario debe importar el paquete
+// remoto de Viper: import _ "github.com/spf13/viper/remote".
+type RemoteProvider struct {
+	Provider   string // "etcd", "etcd3", "consul", "firestore" o "nats"
+	Endpoint   string // ej: "http://127.0.0.1:8500"
+	Path       string // ej: "config/filingo.json"
+	ConfigType string // Formato del contenido, ej: "json"; ver SupportedConfigTypes
+	// SecretKeyring es la ruta del llavero para descifrar el contenido cifrado con
+	// crypt. Vacío si el contenido no está cifrado.
+	SecretKeyring string
+	// Revision, opcional, devuelve la revisión actual del contenido remoto (ej: el
+	// ModifyIndex de Consul o la revisión de etcd). Viper no la expone, así que hay que
+	// obtenerla con el cliente del almacén. Ver Loader.Reload.
+	Revision func() (string, error)
+}
+
+// remoteRevision devuelve la revisión del contenido de opts.RemoteProvider, o "" si no
+// hay almacén remoto o no informa de su revisión.
+func remoteRevision(opts Options) (string, error) {
+	rp := opts.RemoteProvider
+	if rp == nil || rp.Revision == nil {
+		return "", nil
+	}
+	revision, err := rp.Revision()
+	if err != nil {
+		return "", fmt.Errorf("error al leer la revisión de la configuración remota %s %q: %w", rp.Provider, rp.Path, err)
+	}
+	return revision, nil
+}
+
+// mergeRemote lee la configuración de opts.RemoteProvider, si hay, y la fusiona en v
+// por encima de los archivos locales, que quedan como base.
+func mergeRemote(v *viper.Viper, opts Options) error {
+	rp := opts.RemoteProvider
+	if rp == nil {
+		return nil
+	}
+	if !IsSupportedConfigType(rp.ConfigType) {
+		return fmt.Errorf("tipo de configuración remota no soportado %q", rp.ConfigType)
+	}
+
+	remote := newViper()
+	remote.SetConfigType(rp.ConfigType)
+	var err error
+	if rp.SecretKeyring != "" {
+		err = remote.AddSecureRemoteProvider(rp.Provider, rp.Endpoint, rp.Path, rp.SecretKeyring)
+	} else {
+		err = remote.AddRemoteProvider(rp.Provider, rp.Endpoint, rp.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("proveedor de configuración remota no válido: %w", err)
+	}
+	if err := remote.ReadRemoteConfig(); err != nil {
+		return fmt.Errorf("error al leer la configuración remota %s %q: %w", rp.Provider, rp.Path, err)
+	}
+	return v.MergeConfigMap(remote.AllSettings())
+}
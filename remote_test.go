@@ -0,0 +1,173 @@
+// remote_test.go
+package configloader
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemote sustituye al paquete remoto de Viper: sirve el contenido de cada ruta.
+type fakeRemote map[string]string
+
+func (f fakeRemote) Get(rp viper.RemoteProvider) (io.Reader, error) {
+	content, ok := f[rp.Path()]
+	if !ok {
+		return nil, errors.New("clave no encontrada")
+	}
+	return bytes.NewBufferString(content), nil
+}
+
+func (f fakeRemote) Watch(rp viper.RemoteProvider) (io.Reader, error) { return f.Get(rp) }
+
+func (f fakeRemote) WatchChannel(viper.RemoteProvider) (<-chan *viper.RemoteResponse, chan bool) {
+	return nil, nil
+}
+
+// useFakeRemote instala f como soporte remoto de Viper durante el test.
+func useFakeRemote(t *testing.T, f fakeRemote) {
+	previous := viper.RemoteConfig
+	viper.RemoteConfig = f
+	t.Cleanup(func() { viper.RemoteConfig = previous })
+}
+
+func TestLoad_RemoteProviderOverridesLocalFile(t *testing.T) {
+	// Arrange: un archivo local como base y sustituciones en Consul.
+	useFakeRemote(t, fakeRemote{"config/filingo.json": `{"database": {"host": "db-consul", "max_connections": 20}}`})
+	tempDir := writeTempConfig(t, "remote.yaml", "application:\n  name: \"filingo\"\ndatabase:\n  host: \"db-local\"\n  port: 5432\n")
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:     "remote",
+		ConfigType:     "yaml",
+		ConfigPaths:    []string{tempDir},
+		RemoteProvider: &RemoteProvider{Provider: "consul", Endpoint: "127.0.0.1:8500", Path: "config/filingo.json", ConfigType: "json"},
+	})
+
+	// Assert: lo remoto gana y lo que solo está en el archivo local se conserva.
+	require.NoError(t, err)
+	assert.Equal(t, "db-consul", cfg.DB.Host)
+	assert.Equal(t, int32(20), cfg.DB.MaxConns)
+	assert.Equal(t, int32(5432), cfg.DB.Port)
+	assert.Equal(t, "filingo", cfg.App.Name)
+}
+
+func TestLoad_RemoteProviderOnly(t *testing.T) {
+	useFakeRemote(t, fakeRemote{"config/filingo": "application:\n  name: \"desde-etcd\"\n"})
+
+	cfg, err := load(Options{
+		ConfigName:     "no-existe",
+		RemoteProvider: &RemoteProvider{Provider: "etcd3", Endpoint: "http://127.0.0.1:2379", Path: "config/filingo", ConfigType: "yaml"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "desde-etcd", cfg.App.Name)
+}
+
+func TestLoad_RemoteProviderErrors(t *testing.T) {
+	useFakeRemote(t, fakeRemote{})
+	tests := map[string]*RemoteProvider{
+		"clave inexistente":     {Provider: "consul", Endpoint: "127.0.0.1:8500", Path: "no/existe", ConfigType: "json"},
+		"proveedor desconocido": {Provider: "zookeeper", Endpoint: "127.0.0.1:2181", Path: "config", ConfigType: "json"},
+		"formato no soportado":  {Provider: "consul", Endpoint: "127.0.0.1:8500", Path: "config", ConfigType: "xml"},
+	}
+	for name, rp := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := load(Options{ConfigName: "no-existe", RemoteProvider: rp})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoader_ReloadSkipsUnchangedRemoteRevision(t *testing.T) {
+	// Arrange: un almacén cuyo contenido y revisión cambian entre recargas.
+	remote := fakeRemote{"config/filingo.json": `{"database": {"host": "db-1"}}`}
+	useFakeRemote(t, remote)
+	revision := "7"
+	revisionCalls := 0
+	loader, err := NewLoader(Options{
+		ConfigName: "no-existe",
+		RemoteProvider: &RemoteProvider{
+			Provider: "consul", Endpoint: "127.0.0.1:8500", Path: "config/filingo.json", ConfigType: "json",
+			Revision: func() (string, error) {
+				revisionCalls++
+				return revision, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	first := loader.Config()
+
+	// Act: misma revisión, aunque el contenido haya cambiado.
+	remote["config/filingo.json"] = `{"database": {"host": "db-2"}}`
+	reloaded, err := loader.Reload()
+
+	// Assert: la recarga se omite.
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Same(t, first, loader.Config())
+	assert.Equal(t, "7", loader.RemoteRevision())
+
+	// Act: nueva revisión.
+	revision = "8"
+	reloaded, err = loader.Reload()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "db-2", loader.Config().DB.Host)
+	assert.Equal(t, "8", loader.RemoteRevision())
+	assert.Equal(t, 3, revisionCalls)
+	assert.Equal(t, "db-1", first.DB.Host)
+}
+
+func TestLoader_ReloadWithoutRevision(t *testing.T) {
+	// Sin revisión no se puede saber si algo cambió: siempre se recarga.
+	remote := fakeRemote{"config/filingo": "application:\n  name: \"uno\"\n"}
+	useFakeRemote(t, remote)
+	loader, err := NewLoader(Options{
+		ConfigName:     "no-existe",
+		RemoteProvider: &RemoteProvider{Provider: "etcd3", Endpoint: "http://127.0.0.1:2379", Path: "config/filingo", ConfigType: "yaml"},
+	})
+	require.NoError(t, err)
+
+	remote["config/filingo"] = "application:\n  name: \"dos\"\n"
+	reloaded, err := loader.Reload()
+
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Empty(t, loader.RemoteRevision())
+	assert.Equal(t, "dos", loader.Config().App.Name)
+}
+
+func TestLoader_ReloadRevisionError(t *testing.T) {
+	useFakeRemote(t, fakeRemote{"config/filingo.json": `{}`})
+	fail := false
+	loader, err := NewLoader(Options{
+		ConfigName: "no-existe",
+		RemoteProvider: &RemoteProvider{
+			Provider: "consul", Endpoint: "127.0.0.1:8500", Path: "config/filingo.json", ConfigType: "json",
+			Revision: func() (string, error) {
+				if fail {
+					return "", errors.New("consul no disponible")
+				}
+				return "1", nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	previous := loader.Config()
+
+	fail = true
+	reloaded, err := loader.Reload()
+
+	require.ErrorContains(t, err, "consul no disponible")
+	assert.False(t, reloaded)
+	assert.Same(t, previous, loader.Config())
+	assert.Equal(t, "1", loader.RemoteRevision())
+}
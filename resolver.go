@@ -0,0 +1,42 @@
+// resolver.go
+
+package configloader
+
+import (
+	"context"
+	"sync"
+)
+
+// ResolverFunc elige la configuración que corresponde a un contexto (ej: según el
+// tenant de la petición). Devuelve nil si no hay ninguna para ese contexto.
+type ResolverFunc func(ctx context.Context) *Config
+
+var (
+	resolverMu sync.RWMutex
+	resolver   ResolverFunc
+)
+
+// SetResolver fija el ResolverFunc que usa FromContextOrResolve. Se llama normalmente
+// una vez al arrancar; nil lo desactiva.
+func SetResolver(fn ResolverFunc) {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	resolver = fn
+}
+
+// FromContextOrResolve devuelve la configuración adjunta a ctx con ToContext o, si no
+// la hay, la que devuelva el ResolverFunc fijado con SetResolver. Devuelve nil y false
+// si no hay ninguna de las dos.
+func FromContextOrResolve(ctx context.Context) (*Config, bool) {
+	if cfg, ok := FromContext(ctx); ok {
+		return cfg, true
+	}
+	resolverMu.RLock()
+	fn := resolver
+	resolverMu.RUnlock()
+	if fn == nil {
+		return nil, false
+	}
+	cfg := fn(ctx)
+	return cfg, cfg != nil
+}
@@ -0,0 +1,42 @@
+// resolver_test.go
+package configloader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantKey struct{}
+
+func TestFromContextOrResolve(t *testing.T) {
+	// Arrange: un resolver que elige la configuración según el tenant del contexto.
+	acme := &Config{App: AppConfig{Name: "acme"}}
+	globex := &Config{App: AppConfig{Name: "globex"}}
+	SetResolver(func(ctx context.Context) *Config {
+		switch ctx.Value(tenantKey{}) {
+		case "acme":
+			return acme
+		case "globex":
+			return globex
+		default:
+			return nil
+		}
+	})
+	t.Cleanup(func() { SetResolver(nil) })
+	attached := &Config{App: AppConfig{Name: "adjunta"}}
+
+	// Act
+	gotAcme, okAcme := FromContextOrResolve(context.WithValue(context.Background(), tenantKey{}, "acme"))
+	gotGlobex, _ := FromContextOrResolve(context.WithValue(context.Background(), tenantKey{}, "globex"))
+	_, okUnknown := FromContextOrResolve(context.WithValue(context.Background(), tenantKey{}, "initech"))
+	gotAttached, _ := FromContextOrResolve(context.WithValue(ToContext(context.Background(), attached), tenantKey{}, "acme"))
+
+	// Assert
+	assert.True(t, okAcme)
+	assert.Same(t, acme, gotAcme)
+	assert.Same(t, globex, gotGlobex)
+	assert.False(t, okUnknown, "Sin configuración para el tenant debería devolver false")
+	assert.Same(t, attached, gotAttached, "La configuración adjunta tiene prioridad sobre el resolver")
+}
@@ -0,0 +1,107 @@
+// schema.go
+
+package configloader
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationPattern describe el formato de time.ParseDuration (ej: "1h30m", "250ms", "0").
+const durationPattern = `^(0|-?([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+$`
+
+// GenerateSchema devuelve un JSON Schema (draft 2020-12) de los archivos de configuración,
+// generado a partir de los tags de Config: cada sección es un objeto con sus claves
+// `mapstructure`, los tags `doc` son descripciones, `min`/`max` son límites y los campos
+// con `required:"true"` o validate:"required" son obligatorios. Las duraciones se
+// describen como texto con el formato de Go (ej: "30s") y las listas admiten también
+// un texto separado por comas. No se prohíben claves adicionales, ya que los archivos
+// pueden contener secciones de plugins. Pensado para guardarlo como config.schema.json
+// y usarlo en el editor o en CI.
+func GenerateSchema() ([]byte, error) {
+	schema := structSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Config"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// structSchema describe un struct como objeto con una propiedad por campo exportado.
+func structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := fieldKey(field)
+		if !field.IsExported() || key == "-" {
+			continue
+		}
+		properties[key] = fieldSchema(field)
+		if isRequiredField(field) {
+			required = append(required, key)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fieldSchema describe un campo: su tipo más las restricciones de sus tags.
+func fieldSchema(field reflect.StructField) map[string]any {
+	schema := typeSchema(field.Type)
+	if doc := field.Tag.Get("doc"); doc != "" {
+		schema["description"] = doc
+	}
+	// Las duraciones se escriben como texto, al que no se aplican minimum ni maximum.
+	if field.Type != reflect.TypeOf(time.Duration(0)) {
+		for tag, keyword := range map[string]string{"min": "minimum", "max": "maximum"} {
+			if bound, err := strconv.ParseFloat(field.Tag.Get(tag), 64); err == nil {
+				schema[keyword] = bound
+			}
+		}
+	}
+	if pattern, ok := field.Tag.Lookup("pattern"); ok {
+		schema["pattern"] = pattern
+	}
+	return schema
+}
+
+// typeSchema describe un tipo Go como lo decodifica la librería.
+func typeSchema(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		// Las listas también admiten un texto separado por comas (ver csvSliceHook).
+		return map[string]any{"type": []string{"array", "string"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	default:
+		return map[string]any{}
+	}
+}
+
+// isRequiredField indica si el campo es obligatorio según `required:"true"` o la
+// regla "required" de su tag `validate`.
+func isRequiredField(field reflect.StructField) bool {
+	return field.Tag.Get("required") == "true" || slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required")
+}
@@ -0,0 +1,153 @@
+// schema_test.go
+package configloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	// Act
+	data, err := GenerateSchema()
+
+	// Assert
+	require.NoError(t, err)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	properties := schema["properties"].(map[string]any)
+	app := properties["application"].(map[string]any)
+	assert.Equal(t, []any{"name"}, app["required"])
+	port := app["properties"].(map[string]any)["port"].(map[string]any)
+	assert.Equal(t, "integer", port["type"])
+	assert.Equal(t, float64(65535), port["maximum"])
+	assert.Equal(t, "Puerto en el que escucha la aplicación", port["description"])
+	lifetime := properties["database"].(map[string]any)["properties"].(map[string]any)["max_connection_life_time"].(map[string]any)
+	assert.Equal(t, "string", lifetime["type"])
+	jitter := properties["refresh"].(map[string]any)["properties"].(map[string]any)["jitter"].(map[string]any)
+	assert.Equal(t, "string", jitter["type"])
+	assert.NotContains(t, jitter, "minimum", "Una duración es texto: min no se traduce a minimum")
+	routes := properties["rate_limit"].(map[string]any)["properties"].(map[string]any)["routes"].(map[string]any)
+	assert.Equal(t, "object", routes["type"])
+	assert.Contains(t, routes["additionalProperties"], "properties")
+}
+
+func TestGenerateSchema_DurationPattern(t *testing.T) {
+	re := regexp.MustCompile(durationPattern)
+	for _, valid := range []string{"0", "30s", "1h30m", "250ms", "1.5h", "-5m", "10µs"} {
+		assert.True(t, re.MatchString(valid), "%q debería ser válida", valid)
+	}
+	for _, invalid := range []string{"", "30", "1 h", "10d", "h"} {
+		assert.False(t, re.MatchString(invalid), "%q no debería ser válida", invalid)
+	}
+}
+
+func TestGenerateSchema_ValidatesSampleConfig(t *testing.T) {
+	// Arrange
+	data, err := GenerateSchema()
+	require.NoError(t, err)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	raw, err := os.ReadFile("config-app_name.yaml.example")
+	require.NoError(t, err)
+	var sample map[string]any
+	require.NoError(t, yaml.Unmarshal(raw, &sample))
+
+	// Act
+	violations := schemaViolations("", schema, sample)
+	invalid := schemaViolations("", schema, map[string]any{
+		"application": map[string]any{"port": 70000},
+		"database":    map[string]any{"max_connection_life_time": "una hora"},
+	})
+
+	// Assert
+	assert.Empty(t, violations)
+	assert.ElementsMatch(t, []string{"application: falta name", "application.port: mayor que el máximo", "database: falta host", "database.max_connection_life_time: no cumple el patrón"}, invalid)
+}
+
+// schemaViolations valida value contra el subconjunto de JSON Schema que emite
+// GenerateSchema (type, properties, required, items, additionalProperties, pattern,
+// minimum y maximum) y devuelve una descripción de cada violación.
+func schemaViolations(path string, schema map[string]any, value any) []string {
+	if !matchesType(schema["type"], value) {
+		return []string{fmt.Sprintf("%s: tipo %T no admitido", path, value)}
+	}
+	var violations []string
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := v[key.(string)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: falta %s", path, key))
+			}
+		}
+		for key, inner := range v {
+			sub, ok := properties[key].(map[string]any)
+			if !ok {
+				sub, ok = schema["additionalProperties"].(map[string]any)
+			}
+			if ok {
+				violations = append(violations, schemaViolations(joinPath(path, key), sub, inner)...)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			violations = append(violations, schemaViolations(fmt.Sprintf("%s[%d]", path, i), schema["items"].(map[string]any), item)...)
+		}
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v) {
+			violations = append(violations, path+": no cumple el patrón")
+		}
+	case int:
+		if minimum, ok := schema["minimum"].(float64); ok && float64(v) < minimum {
+			violations = append(violations, path+": menor que el mínimo")
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && float64(v) > maximum {
+			violations = append(violations, path+": mayor que el máximo")
+		}
+	}
+	return violations
+}
+
+// matchesType indica si value es de alguno de los tipos JSON de schemaType.
+func matchesType(schemaType any, value any) bool {
+	types, ok := schemaType.([]any)
+	if !ok {
+		if schemaType == nil {
+			return true
+		}
+		types = []any{schemaType}
+	}
+	var kind string
+	switch value.(type) {
+	case map[string]any:
+		kind = "object"
+	case []any:
+		kind = "array"
+	case string:
+		kind = "string"
+	case bool:
+		kind = "boolean"
+	case int:
+		return slices.Contains(types, "integer") || slices.Contains(types, "number")
+	case float64:
+		kind = "number"
+	}
+	return slices.Contains(types, any(kind))
+}
+
+// joinPath añade key a la ruta con puntos path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
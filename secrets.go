@@ -0,0 +1,91 @@
+// secrets.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+// ErrSecretNotFound indica que el almacén de secretos no tiene la entrada pedida.
+var ErrSecretNotFound = errors.New("configloader: secreto no encontrado")
+
+// SecretProvider resuelve referencias a secretos de un esquema concreto. Un valor
+// "esquema://referencia" o "esquema:referencia" en cualquier campo de Config se
+// sustituye durante la carga por Secret("referencia") del proveedor registrado para
+// ese esquema.
+type SecretProvider interface {
+	Secret(ref string) (string, error)
+}
+
+// defaultSecretProviders son los proveedores disponibles sin configuración.
+var defaultSecretProviders = map[string]SecretProvider{
+	"keyring": KeyringProvider{},
+}
+
+// KeyringProvider lee secretos del almacén del sistema operativo (Keychain en macOS,
+// Secret Service en Linux, Credential Manager en Windows). Resuelve referencias
+// "servicio/clave", es decir, valores "keyring://servicio/clave".
+type KeyringProvider struct{}
+
+// Secret devuelve el secreto de la clave indicada en ref ("servicio/clave").
+func (KeyringProvider) Secret(ref string) (string, error) {
+	service, user, ok := strings.Cut(ref, "/")
+	if !ok || service == "" || user == "" {
+		return "", fmt.Errorf("la referencia %q no tiene el formato servicio/clave", ref)
+	}
+	secret, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("%w en el keyring: %s", ErrSecretNotFound, ref)
+	}
+	return secret, err
+}
+
+// MemorySecretProvider es un SecretProvider en memoria, indexado por referencia.
+// Pensado para tests: sustituye a un proveedor real en Options.SecretProviders.
+type MemorySecretProvider map[string]string
+
+// Secret devuelve el secreto guardado para ref o ErrSecretNotFound.
+func (m MemorySecretProvider) Secret(ref string) (string, error) {
+	secret, ok := m[ref]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+	}
+	return secret, nil
+}
+
+// resolveSecretRefs sustituye en v cada campo de keys cuyo valor sea una referencia
+// "esquema://..." o "esquema:..." de un esquema con proveedor. Los valores cuyo
+// prefijo no es un esquema registrado (ej: "localhost:6379") no se tocan. El secreto
+// se fija en v registrándolo en ov. opts.SecretProviders se suma a los proveedores por defecto y tiene prioridad sobre ellos.
+func resolveSecretRefs(v *viper.Viper, ov overrides, opts Options, keys []string) error {
+	providers := make(map[string]SecretProvider, len(defaultSecretProviders)+len(opts.SecretProviders))
+	for scheme, provider := range defaultSecretProviders {
+		providers[scheme] = provider
+	}
+	for scheme, provider := range opts.SecretProviders {
+		providers[scheme] = provider
+	}
+
+	for _, key := range keys {
+		value, ok := v.Get(key).(string)
+		if !ok {
+			continue
+		}
+		scheme, ref, found := strings.Cut(value, ":")
+		provider := providers[scheme]
+		if !found || provider == nil {
+			continue
+		}
+		secret, err := provider.Secret(strings.TrimPrefix(ref, "//"))
+		if err != nil {
+			return fmt.Errorf("error al resolver el secreto de %s: %w", key, err)
+		}
+		ov.resolve(v, key, secret)
+	}
+	return nil
+}
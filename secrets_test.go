@@ -0,0 +1,149 @@
+// secrets_test.go
+package configloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestLoad_SecretProviderRefs(t *testing.T) {
+	// Arrange
+	yamlContent := `
+database:
+  host: "db.local"
+  password: "keyring://filingo/db-password"
+`
+	tempDir := writeTempConfig(t, "secrets.yaml", yamlContent)
+	opts := Options{
+		ConfigName:      "secrets",
+		ConfigType:      "yaml",
+		ConfigPaths:     []string{tempDir},
+		SecretProviders: map[string]SecretProvider{"keyring": MemorySecretProvider{"filingo/db-password": "s3cr3t"}},
+	}
+
+	// Act
+	cfg, err := load(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.DB.Password)
+	assert.Equal(t, "db.local", cfg.DB.Host)
+}
+
+func TestLoad_SecretProviderMissingEntry(t *testing.T) {
+	// Arrange
+	tempDir := writeTempConfig(t, "secrets.yaml", "redis:\n  password: \"keyring://filingo/redis\"\n")
+	opts := Options{
+		ConfigName:      "secrets",
+		ConfigType:      "yaml",
+		ConfigPaths:     []string{tempDir},
+		SecretProviders: map[string]SecretProvider{"keyring": MemorySecretProvider{}},
+	}
+
+	// Act
+	_, err := load(opts)
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+	assert.Contains(t, err.Error(), "redis.password")
+}
+
+func TestKeyringProvider(t *testing.T) {
+	// Arrange: sustituye el keyring del sistema por el simulado de la librería.
+	keyring.MockInit()
+	require.NoError(t, keyring.Set("filingo", "api-token", "token-123"))
+
+	// Act
+	secret, err := KeyringProvider{}.Secret("filingo/api-token")
+	_, errMissing := KeyringProvider{}.Secret("filingo/nope")
+	_, errFormat := KeyringProvider{}.Secret("sin-barra")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "token-123", secret)
+	assert.ErrorIs(t, errMissing, ErrSecretNotFound)
+	assert.Error(t, errFormat)
+}
+
+// flakyProvider falla las primeras veces que se le pide un secreto.
+type flakyProvider struct {
+	failures int
+	calls    int
+}
+
+func (p *flakyProvider) Secret(ref string) (string, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return "", errors.New("servicio no disponible")
+	}
+	return "s3cr3t", nil
+}
+
+func TestInit_RetriesTransientFailures(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange: el proveedor remoto falla en el primer intento.
+	tempDir := writeTempConfig(t, "remote.yaml", "database:\n  password: \"vault://db\"\n")
+	provider := &flakyProvider{failures: 1}
+	opts := Options{
+		ConfigName:       "remote",
+		ConfigType:       "yaml",
+		ConfigPaths:      []string{tempDir},
+		SecretProviders:  map[string]SecretProvider{"vault": provider},
+		InitRetries:      3,
+		InitRetryBackoff: time.Millisecond,
+	}
+
+	// Act
+	err := Init(opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.calls)
+	assert.Equal(t, "s3cr3t", Get().DB.Password)
+}
+
+func TestInitContext_StopsRetryingOnDeadline(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange: un proveedor que nunca se recupera y un plazo corto.
+	tempDir := writeTempConfig(t, "remote.yaml", "database:\n  password: \"vault://db\"\n")
+	provider := &flakyProvider{failures: 1000}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := InitContext(ctx, Options{
+		ConfigName:       "remote",
+		ConfigType:       "yaml",
+		ConfigPaths:      []string{tempDir},
+		SecretProviders:  map[string]SecretProvider{"vault": provider},
+		InitRetries:      1000,
+		InitRetryBackoff: 10 * time.Millisecond,
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "servicio no disponible")
+	assert.Less(t, provider.calls, 10)
+}
+
+func TestInit_MissingFileDoesNotRetry(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Act: sin archivo la carga no falla, así que no se reintenta ni se espera.
+	start := time.Now()
+	err := Init(Options{ConfigName: "inexistente", ConfigType: "yaml", ConfigPaths: []string{t.TempDir()}, InitRetries: 3, InitRetryBackoff: time.Second})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,64 @@
+// sections.go
+
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// sections contiene las secciones registradas por plugins: clave de nivel superior
+// -> puntero al struct de configuración del plugin (su tipo y sus valores por defecto).
+var (
+	sectionsMu sync.RWMutex
+	sections   = map[string]any{}
+)
+
+// RegisterSection registra la sección de configuración de un plugin bajo la clave de
+// nivel superior key (ej: "payments"). target debe ser un puntero a struct con tags
+// `mapstructure`; su contenido actual se usa como valores por defecto.
+//
+// En cada carga posterior se decodifica la clave en una copia nueva de target, que se
+// obtiene con Config.Section(key); target en sí no se modifica. Entra en pánico si target
+// no es un puntero a struct, ya que es un error de programación.
+func RegisterSection(key string, target any) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("configloader: RegisterSection(%q) necesita un puntero a struct, recibió %T", key, target))
+	}
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	sections[strings.ToLower(key)] = target
+}
+
+// Section devuelve la sección de plugin decodificada para key, como un puntero del
+// mismo tipo que el registrado con RegisterSection (ej: cfg.Section("payments").(*PaymentsConfig)).
+// Devuelve false si la sección no estaba registrada en el momento de la carga.
+func (c *Config) Section(key string) (any, bool) {
+	section, ok := c.sections[strings.ToLower(key)]
+	return section, ok
+}
+
+// decodeSections decodifica cada sección registrada en una copia nueva de su destino.
+func decodeSections(v *viper.Viper) (map[string]any, error) {
+	sectionsMu.RLock()
+	defer sectionsMu.RUnlock()
+	if len(sections) == 0 {
+		return nil, nil
+	}
+
+	decoded := make(map[string]any, len(sections))
+	for key, target := range sections {
+		section := reflect.New(reflect.TypeOf(target).Elem())
+		section.Elem().Set(reflect.ValueOf(target).Elem())
+		if err := v.UnmarshalKey(key, section.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: sección %q: %w", ErrConfigDecode, key, err)
+		}
+		decoded[key] = section.Interface()
+	}
+	return decoded, nil
+}
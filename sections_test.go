@@ -0,0 +1,54 @@
+// sections_test.go
+package configloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paymentsConfig es la sección de configuración de un plugin de ejemplo.
+type paymentsConfig struct {
+	Provider string        `mapstructure:"provider"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Retries  int           `mapstructure:"retries"`
+}
+
+func TestLoad_RegisteredSection(t *testing.T) {
+	// Arrange: el plugin registra su sección con un valor por defecto.
+	defaults := &paymentsConfig{Retries: 3}
+	RegisterSection("payments", defaults)
+	t.Cleanup(func() {
+		sectionsMu.Lock()
+		delete(sections, "payments")
+		sectionsMu.Unlock()
+	})
+	yamlContent := `
+payments:
+  provider: "stripe"
+  timeout: "5s"
+`
+	tempDir := writeTempConfig(t, "plugins.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "plugins", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	section, ok := cfg.Section("payments")
+	require.True(t, ok, "La sección registrada debería estar disponible")
+	payments := section.(*paymentsConfig)
+	assert.Equal(t, "stripe", payments.Provider)
+	assert.Equal(t, 5*time.Second, payments.Timeout)
+	assert.Equal(t, 3, payments.Retries, "Debería conservarse el valor por defecto registrado")
+	assert.Empty(t, defaults.Provider, "El destino registrado no debería modificarse")
+
+	_, ok = cfg.Section("shipping")
+	assert.False(t, ok)
+}
+
+func TestRegisterSection_PanicsOnNonPointer(t *testing.T) {
+	assert.Panics(t, func() { RegisterSection("payments", paymentsConfig{}) })
+}
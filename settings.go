@@ -0,0 +1,61 @@
+// settings.go
+
+package configloader
+
+import (
+	"reflect"
+	"strings"
+)
+
+// AllSettings devuelve toda la configuración efectiva como mapa anidado, tal como
+// quedó al fusionar las fuentes (valores por defecto, archivos y entorno), incluidas
+// las claves que no corresponden a ningún campo de Config (ej: una sección que aún no
+// está en el struct). Las variables de entorno de claves que no son campos de Config
+// solo aparecen si la clave existe en otra fuente. Los secretos se incluyen en claro;
+// ver Redacted. Devuelve una copia: modificarla no afecta a c.
+func (c *Config) AllSettings() map[string]any {
+	if c.settings == nil {
+		return map[string]any{}
+	}
+	return deepCopy(reflect.ValueOf(c.settings)).Interface().(map[string]any)
+}
+
+// maskSettingsSecrets sustituye en settings, si están, los valores de los campos
+// `sensitive:"true"` de Config por maskedValue.
+func maskSettingsSecrets(settings map[string]any) {
+	walkFields(reflect.ValueOf(Config{}), "", func(path string, field reflect.StructField, _ reflect.Value) {
+		if isSensitive(field) {
+			maskSettingsKey(settings, path)
+		}
+	})
+}
+
+// maskSettingsKey sustituye en settings, si está, el valor de la clave path (ruta con
+// puntos) por maskedValue. Si el valor es una sección, se enmascaran todos sus valores.
+func maskSettingsKey(settings map[string]any, path string) {
+	parts := strings.Split(path, ".")
+	m := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]any)
+		if !ok {
+			return
+		}
+		m = next
+	}
+	if value, ok := m[parts[len(parts)-1]]; ok {
+		m[parts[len(parts)-1]] = maskedLeaves(value)
+	}
+}
+
+// maskedLeaves devuelve value con todos sus valores sustituidos por maskedValue,
+// conservando las claves de los mapas anidados.
+func maskedLeaves(value any) any {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return maskedValue
+	}
+	for key, item := range m {
+		m[key] = maskedLeaves(item)
+	}
+	return m
+}
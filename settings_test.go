@@ -0,0 +1,46 @@
+// settings_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_AllSettings(t *testing.T) {
+	// Arrange: una sección que Config no recoge, un valor del entorno y un secreto.
+	yamlContent := `
+database:
+  host: "db-file"
+  password: "pg-secreto"
+features:
+  beta: true
+  rollout: 25
+`
+	tempDir := writeTempConfig(t, "settings.yaml", yamlContent)
+	t.Setenv("MYAPP_DATABASE_HOST", "db-env")
+
+	// Act
+	cfg, err := load(Options{ConfigName: "settings", ConfigType: "yaml", ConfigPaths: []string{tempDir}, EnvPrefix: "MYAPP"})
+	require.NoError(t, err)
+	settings := cfg.AllSettings()
+
+	// Assert: incluye lo no mapeado, respeta la precedencia y los valores por defecto.
+	assert.Equal(t, map[string]any{"beta": true, "rollout": 25}, settings["features"])
+	database := settings["database"].(map[string]any)
+	assert.Equal(t, "db-env", database["host"])
+	assert.Equal(t, "pg-secreto", database["password"])
+	assert.Equal(t, "schema_migrations", settings["migrations"].(map[string]any)["table"])
+
+	// Es una copia, y Redacted enmascara los secretos.
+	database["host"] = "cambiado"
+	assert.Equal(t, "db-env", cfg.AllSettings()["database"].(map[string]any)["host"])
+	redacted := cfg.Redacted().AllSettings()["database"].(map[string]any)
+	assert.Equal(t, maskedValue, redacted["password"])
+	assert.Equal(t, "pg-secreto", cfg.AllSettings()["database"].(map[string]any)["password"])
+}
+
+func TestConfig_AllSettingsWithoutLoad(t *testing.T) {
+	assert.Empty(t, validConfig().AllSettings())
+}
@@ -0,0 +1,34 @@
+// strict.go
+
+package configloader
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// unknownKeys filtra las claves que mapstructure no pudo asignar a ningún campo del
+// destino (unused) y devuelve, ordenadas, las que vienen de un archivo. No cuentan las
+// claves que la librería usa por su cuenta: las secciones de plugins registradas,
+// `inherit` y las variantes *_file de los campos (fields).
+func unknownKeys(v *viper.Viper, unused, fields []string) []string {
+	sectionsMu.RLock()
+	defer sectionsMu.RUnlock()
+
+	var unknown []string
+	for _, key := range unused {
+		key = strings.ToLower(key)
+		top, _, _ := strings.Cut(key, ".")
+		_, isSection := sections[top]
+		switch {
+		case !v.InConfig(key), isSection, key == inheritKey:
+		case strings.HasSuffix(key, fileKeySuffix) && slices.Contains(fields, strings.TrimSuffix(key, fileKeySuffix)):
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
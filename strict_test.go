@@ -0,0 +1,66 @@
+// strict_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_StrictUnknownKeys(t *testing.T) {
+	// Arrange: una sección mal escrita y un campo desconocido dentro de una sección válida.
+	yamlContent := `
+databse:
+  host: "db"
+http:
+  port: 8080
+  prot: 9090
+rate_limit:
+  routes:
+    /api/upload:
+      burst: 5
+`
+	tempDir := writeTempConfig(t, "strict.yaml", yamlContent)
+	opts := Options{ConfigName: "strict", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act
+	_, lenientErr := load(opts)
+	opts.Strict = true
+	_, err := load(opts)
+
+	// Assert: por defecto se ignoran; en modo estricto se enumeran todas, y las
+	// entradas de un mapa (rate_limit.routes) no cuentan como desconocidas.
+	require.NoError(t, lenientErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrConfigDecode)
+	assert.Contains(t, err.Error(), "claves desconocidas en la configuración: databse, http.prot")
+}
+
+func TestLoad_StrictIgnoresLibraryKeys(t *testing.T) {
+	// Arrange: una variante *_file, una sección de plugin y una clave solo del entorno.
+	type paymentsConfig struct {
+		Endpoint string `mapstructure:"endpoint"`
+	}
+	RegisterSection("strict_payments", &paymentsConfig{})
+	t.Cleanup(func() {
+		sectionsMu.Lock()
+		delete(sections, "strict_payments")
+		sectionsMu.Unlock()
+	})
+	tempDir := writeTempConfig(t, "strict.yaml", "database:\n  host: \"db\"\n  password_file: \"\"\nstrict_payments:\n  endpoint: \"https://pay.example\"\n")
+	t.Setenv("MYAPP_FEATURES_BETA", "true")
+
+	// Act
+	_, err := load(Options{
+		ConfigName:  "strict",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{tempDir},
+		EnvPrefix:   "MYAPP",
+		Defaults:    map[string]any{"features.beta": false},
+		Strict:      true,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+}
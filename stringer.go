@@ -0,0 +1,67 @@
+// stringer.go
+
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// String devuelve la configuración en el formato de %+v, con los campos
+// `sensitive:"true"` enmascarados, de modo que se puede escribir en los logs.
+func (c Config) String() string { return redactedString(reflect.ValueOf(c)) }
+
+// String devuelve la sección en el formato de %+v, con la contraseña enmascarada.
+func (d DBConfig) String() string { return redactedString(reflect.ValueOf(d)) }
+
+// String devuelve la sección en el formato de %+v, con la contraseña enmascarada.
+func (r RedisConfig) String() string { return redactedString(reflect.ValueOf(r)) }
+
+// String devuelve la sección en el formato de %+v, con los secretos enmascarados.
+func (o OAuthConfig) String() string { return redactedString(reflect.ValueOf(o)) }
+
+// String devuelve la sección en el formato de %+v, con la clave privada enmascarada.
+func (t TokenConfig) String() string { return redactedString(reflect.ValueOf(t)) }
+
+// String devuelve la sección en el formato de %+v, con la contraseña SASL enmascarada.
+func (k KafkaConfig) String() string { return redactedString(reflect.ValueOf(k)) }
+
+// String devuelve la sección en el formato de %+v, con la contraseña enmascarada.
+func (s KafkaSASLConfig) String() string { return redactedString(reflect.ValueOf(s)) }
+
+// redactedString escribe el struct v como lo haría %+v, pero sustituyendo el valor de
+// los campos secretos por maskedValue, también en las secciones anidadas. Los campos
+// no exportados se omiten.
+func redactedString(v reflect.Value) string {
+	var b strings.Builder
+	writeRedacted(&b, v)
+	return b.String()
+}
+
+// writeRedacted escribe en b los campos exportados del struct v.
+func writeRedacted(b *strings.Builder, v reflect.Value) {
+	t := v.Type()
+	b.WriteByte('{')
+	first := true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(field.Name + ":")
+		switch {
+		case isSensitive(field):
+			b.WriteString(maskedValue)
+		case isSection(field.Type):
+			writeRedacted(b, v.Field(i))
+		default:
+			fmt.Fprintf(b, "%v", v.Field(i).Interface())
+		}
+	}
+	b.WriteByte('}')
+}
@@ -0,0 +1,41 @@
+// stringer_test.go
+package configloader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigString_MasksSecrets(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.DB.Password = "db-secreto"
+	cfg.Redis = RedisConfig{Address: "localhost:6379", Password: "redis-secreto"}
+	cfg.OAuth2 = OAuthConfig{GoogleClientID: "client-id", GoogleClientSecret: "oauth-secreto", SessionSecret: "sesion-secreta"}
+	cfg.Token.PrivateKeyB64 = "clave-privada"
+
+	// Act: las formas habituales de volcar la configuración a un log.
+	outputs := []string{
+		fmt.Sprintf("%v", cfg),
+		fmt.Sprintf("%+v", *cfg),
+		fmt.Sprint(cfg.DB, cfg.Redis, cfg.OAuth2, cfg.Token),
+		cfg.String(),
+	}
+
+	// Assert
+	for _, out := range outputs {
+		for _, secret := range []string{"db-secreto", "redis-secreto", "oauth-secreto", "sesion-secreta", "clave-privada"} {
+			assert.NotContains(t, out, secret)
+		}
+		assert.Contains(t, out, "Password:"+maskedValue)
+	}
+	assert.Contains(t, outputs[0], "DB:{Driver: User: Password:******** Host:localhost Port:5432")
+	assert.Contains(t, outputs[0], "GoogleClientID:client-id")
+}
+
+func TestRedisConfigString(t *testing.T) {
+	r := RedisConfig{Enabled: true, Address: "localhost:6379", Password: "redis-secreto", PoolSize: 20}
+	assert.Equal(t, "{Enabled:true Address:localhost:6379 Password:******** DB:0 PoolSize:20 DialTimeout:0s}", r.String())
+}
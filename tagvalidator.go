@@ -0,0 +1,100 @@
+// tagvalidator.go
+
+package configloader
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// structValidator valida los tags `validate` de Config (ver Options.Validate). Es
+// seguro para uso concurrente y guarda en caché el análisis de cada struct.
+var structValidator = newStructValidator()
+
+// newStructValidator crea el validador con los nombres de campo de los tags
+// `mapstructure` (los errores dicen "database.port", no "DB.Port") y con las reglas
+// mindur y maxdur para time.Duration. En las secciones de Config, las restricciones
+// que ya declaran los tags de Validate se traducen a reglas (ver tagRules), así que
+// cada campo las declara una sola vez.
+func newStructValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(fieldKey)
+	_ = validate.RegisterValidation("mindur", durationBound(func(d, bound time.Duration) bool { return d >= bound }))
+	_ = validate.RegisterValidation("maxdur", durationBound(func(d, bound time.Duration) bool { return d <= bound }))
+	registerTagRules(validate, reflect.TypeOf(Config{}))
+	return validate
+}
+
+// registerTagRules registra en validate las reglas de tagRules de los campos de t y
+// de sus secciones anidadas.
+func registerTagRules(validate *validator.Validate, t reflect.Type) {
+	rules := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if isSection(field.Type) {
+			registerTagRules(validate, field.Type)
+			continue
+		}
+		if rule := tagRules(field); rule != "" {
+			rules[field.Name] = rule
+		}
+	}
+	if len(rules) > 0 {
+		validate.RegisterStructValidationMapRules(rules, reflect.Zero(t).Interface())
+	}
+}
+
+// tagRules devuelve las reglas del validador de un campo: las de los tags
+// required:"true", min, max y maxdur, más las de su tag `validate` (ej: mindur, que
+// solo existe ahí). Devuelve "" si el campo no tiene ninguna.
+func tagRules(field reflect.StructField) string {
+	var rules []string
+	if field.Tag.Get("required") == "true" {
+		rules = append(rules, "required")
+	}
+	for _, name := range []string{"min", "max", "maxdur"} {
+		if bound, ok := field.Tag.Lookup(name); ok {
+			rules = append(rules, name+"="+bound)
+		}
+	}
+	own := field.Tag.Get("validate")
+	switch {
+	case len(rules) == 0:
+		return own
+	case own == "":
+		return strings.Join(rules, ",")
+	case own == "omitempty" || strings.HasPrefix(own, "omitempty,"):
+		// omitempty solo tiene efecto como primera regla.
+		return own + "," + strings.Join(rules, ",")
+	default:
+		return strings.Join(rules, ",") + "," + own
+	}
+}
+
+// durationBound crea una regla que compara un time.Duration con el parámetro del tag
+// (ej: "1m"). Un parámetro que no es una duración o un campo de otro tipo no la cumplen.
+func durationBound(ok func(d, bound time.Duration) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Type() != reflect.TypeOf(time.Duration(0)) {
+			return false
+		}
+		bound, err := time.ParseDuration(fl.Param())
+		if err != nil {
+			return false
+		}
+		return ok(time.Duration(fl.Field().Int()), bound)
+	}
+}
+
+// validateStructTags aplica los tags `validate` a target, un puntero a struct (*Config
+// o el tipo de Load). Devuelve nil o un validator.ValidationErrors con una entrada
+// por campo inválido.
+func validateStructTags(target any) error {
+	return structValidator.Struct(target)
+}
@@ -0,0 +1,93 @@
+// tagvalidator_test.go
+package configloader
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ValidateTags(t *testing.T) {
+	// Arrange: puerto fuera de rango, host vacío y un token demasiado corto.
+	yamlContent := `
+application:
+  name: "filingo"
+  port: 8080
+http:
+  port: 70000
+database:
+  port: 5432
+tokens:
+  duration: "30s"
+`
+	tempDir := writeTempConfig(t, "tags.yaml", yamlContent)
+	opts := Options{ConfigName: "tags", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act
+	_, errDefault := load(opts)
+	opts.Validate = true
+	_, err := load(opts)
+
+	// Assert
+	require.NoError(t, errDefault, "Sin la opción no se aplican los tags")
+	var validationErrs validator.ValidationErrors
+	require.True(t, errors.As(err, &validationErrs), "El error debería envolver validator.ValidationErrors")
+	failed := map[string]string{}
+	for _, fe := range validationErrs {
+		failed[fe.Namespace()] = fe.Tag()
+	}
+	assert.Equal(t, map[string]string{
+		"Config.http.port":       "max",
+		"Config.database.host":   "required",
+		"Config.tokens.duration": "mindur",
+	}, failed)
+}
+
+func TestValidateStructTags_DurationBounds(t *testing.T) {
+	cfg := validConfig()
+	for duration, valid := range map[time.Duration]bool{
+		0:              true, // omitempty: sin configurar no se valida.
+		time.Minute:    true,
+		24 * time.Hour: true,
+		time.Second:    false,
+		25 * time.Hour: false,
+	} {
+		cfg.Token.Duration = duration
+		err := validateStructTags(cfg)
+		assert.Equal(t, valid, err == nil, "duración %s: %v", duration, err)
+	}
+}
+
+func TestTagRules(t *testing.T) {
+	type sample struct {
+		Name     string        `required:"true"`
+		Port     int32         `min:"1" max:"65535"`
+		Duration time.Duration `maxdur:"24h" validate:"omitempty,mindur=1m"`
+		Mode     string        `validate:"oneof=a b"`
+		Retries  int           `min:"0" validate:"lte=10"`
+		Plain    string
+	}
+	want := map[string]string{
+		"Name":     "required",
+		"Port":     "min=1,max=65535",
+		"Duration": "omitempty,mindur=1m,maxdur=24h",
+		"Mode":     "oneof=a b",
+		"Retries":  "min=0,lte=10",
+		"Plain":    "",
+	}
+	typ := reflect.TypeOf(sample{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Act
+		got := tagRules(field)
+
+		// Assert
+		assert.Equal(t, want[field.Name], got, "campo %s", field.Name)
+	}
+}
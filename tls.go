@@ -0,0 +1,146 @@
+// tls.go
+
+package configloader
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Modos admitidos en TLSConfig.ClientAuth.
+const (
+	ClientAuthNone    = "none"    // No se pide certificado al cliente.
+	ClientAuthRequire = "require" // Se exige certificado, sin verificarlo contra una CA.
+	ClientAuthVerify  = "verify"  // Se exige certificado y se verifica contra ClientCAFile (mTLS).
+)
+
+// tlsVersions traduce los valores admitidos en TLSConfig.MinVersion.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSConfig contiene la configuración TLS de un servidor (HTTP o gRPC), incluida la
+// autenticación mutua (mTLS) de clientes, o de un cliente (ej: la conexión a la base de datos).
+type TLSConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	CertFile     string `mapstructure:"cert_file" file:"exists" fileif:"Enabled=true"`
+	KeyFile      string `mapstructure:"key_file" file:"exists" fileif:"Enabled=true"`
+	CAFile       string `mapstructure:"ca_file" file:"exists" fileif:"Enabled=true"`        // CA con la que un cliente verifica al servidor; vacío: la del sistema
+	MinVersion   string `mapstructure:"min_version" pattern:"^1[.][0-3]$"`                  // "1.0" a "1.3"; vacío: "1.2"
+	ClientAuth   string `mapstructure:"client_auth"`                                        // "none" (por defecto), "require" o "verify"
+	ClientCAFile string `mapstructure:"client_ca_file" file:"exists" fileif:"Enabled=true"` // Obligatorio cuando ClientAuth es "verify"
+}
+
+// BuildTLSConfig construye un *tls.Config listo para usar, en un servidor o en un
+// cliente: carga el par certificado/clave si se indica, la CA de CAFile (RootCAs), la
+// de clientes (ClientCAs) y fija ClientAuth y MinVersion. Si Enabled es false devuelve
+// nil, nil: TLS desactivado. Devuelve un error claro si falta algún archivo indicado,
+// si solo se indica uno de CertFile y KeyFile o si no se indica ninguno de los dos ni CAFile.
+func (t TLSConfig) BuildTLSConfig() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	if t.CertFile == "" && t.KeyFile == "" && t.CAFile == "" {
+		return nil, errors.New("tls: enabled es true pero no se indicó cert_file/key_file ni ca_file")
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, errors.New("tls: cert_file y key_file deben indicarse juntos")
+	}
+	files := []struct{ key, path string }{{"cert_file", t.CertFile}, {"key_file", t.KeyFile}, {"ca_file", t.CAFile}}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			return nil, fmt.Errorf("tls: no se puede acceder a %s %q: %w", file.key, file.path, err)
+		}
+	}
+	clientAuth, err := t.clientAuthType()
+	if err != nil {
+		return nil, err
+	}
+	minVersion, err := t.minVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{ClientAuth: clientAuth, MinVersion: minVersion}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error al cargar el certificado TLS: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	if t.CAFile != "" {
+		if tlsCfg.RootCAs, err = loadCertPool(t.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	if t.ClientCAFile != "" {
+		if tlsCfg.ClientCAs, err = loadCertPool(t.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+	return tlsCfg, nil
+}
+
+// minVersion traduce MinVersion a su constante de crypto/tls; vacío es TLS 1.2.
+func (t TLSConfig) minVersion() (uint16, error) {
+	if t.MinVersion == "" {
+		return tls.VersionTLS12, nil
+	}
+	version, ok := tlsVersions[t.MinVersion]
+	if !ok {
+		return 0, fmt.Errorf("tls: min_version %q no es válido (usa 1.0, 1.1, 1.2 o 1.3)", t.MinVersion)
+	}
+	return version, nil
+}
+
+// TLSConfig construye un *tls.Config listo para usar en un servidor, como
+// BuildTLSConfig pero sin tener en cuenta Enabled.
+func (t *TLSConfig) TLSConfig() (*tls.Config, error) {
+	enabled := *t
+	enabled.Enabled = true
+	return enabled.BuildTLSConfig()
+}
+
+// clientAuthType traduce ClientAuth a su constante de crypto/tls y valida que
+// exista una CA de clientes cuando se exige verificación.
+func (t TLSConfig) clientAuthType() (tls.ClientAuthType, error) {
+	switch t.ClientAuth {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequire:
+		return tls.RequireAnyClientCert, nil
+	case ClientAuthVerify:
+		if t.ClientCAFile == "" {
+			return tls.NoClientCert, errors.New("tls: client_ca_file es obligatorio cuando client_auth es \"verify\"")
+		}
+		if _, err := os.Stat(t.ClientCAFile); err != nil {
+			return tls.NoClientCert, fmt.Errorf("tls: no se puede acceder a client_ca_file: %w", err)
+		}
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("tls: client_auth %q no es válido (usa none, require o verify)", t.ClientAuth)
+	}
+}
+
+// loadCertPool lee un archivo PEM con uno o más certificados de CA.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el archivo de CA %q: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("el archivo de CA %q no contiene certificados PEM válidos", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,200 @@
+// tls_test.go
+package configloader
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate genera un certificado autofirmado y su clave en dir,
+// y devuelve las rutas de ambos archivos PEM.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "configloader-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestTLSConfig_VerifyClientAuth(t *testing.T) {
+	// Arrange: el mismo certificado autofirmado hace de servidor y de CA de clientes.
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cfg := TLSConfig{
+		Enabled:      true,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientAuth:   ClientAuthVerify,
+		ClientCAFile: certFile,
+	}
+
+	// Act
+	tlsCfg, err := cfg.BuildTLSConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+	assert.NotNil(t, tlsCfg.ClientCAs, "Debería cargarse la CA de clientes")
+}
+
+func TestTLSConfig_ClientAuthModes(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	tests := map[string]tls.ClientAuthType{
+		"":                tls.NoClientCert,
+		ClientAuthNone:    tls.NoClientCert,
+		ClientAuthRequire: tls.RequireAnyClientCert,
+	}
+	for mode, expected := range tests {
+		cfg := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientAuth: mode}
+		tlsCfg, err := cfg.BuildTLSConfig()
+		require.NoError(t, err, "client_auth %q", mode)
+		assert.Equal(t, expected, tlsCfg.ClientAuth, "client_auth %q", mode)
+	}
+}
+
+func TestTLSConfig_VerifyRequiresClientCAFile(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	// Sin client_ca_file.
+	cfg := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientAuth: ClientAuthVerify}
+	_, err := cfg.BuildTLSConfig()
+	require.Error(t, err, "verify sin client_ca_file debería fallar")
+
+	// Con un client_ca_file que no existe.
+	cfg.ClientCAFile = filepath.Join(t.TempDir(), "no-existe.pem")
+	_, err = cfg.BuildTLSConfig()
+	require.Error(t, err, "verify con un client_ca_file inexistente debería fallar")
+}
+
+func TestTLSConfig_InvalidClientAuth(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cfg := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientAuth: "sometimes"}
+	_, err := cfg.BuildTLSConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sometimes")
+}
+
+func TestTLSConfig_TLSConfigIgnoresEnabled(t *testing.T) {
+	// Arrange: TLS desactivado, pero con certificado.
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cfg := TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientAuth: ClientAuthRequire}
+
+	// Act
+	tlsCfg, err := cfg.TLSConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.Equal(t, tls.RequireAnyClientCert, tlsCfg.ClientAuth)
+	assert.False(t, cfg.Enabled, "No debería modificar la configuración")
+}
+
+func TestTLSConfig_BuildTLSConfig(t *testing.T) {
+	// Arrange
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cfg := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, CAFile: certFile, MinVersion: "1.3"}
+
+	// Act
+	tlsCfg, err := cfg.BuildTLSConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.NotNil(t, tlsCfg.RootCAs, "Debería cargarse la CA de ca_file")
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsCfg.MinVersion)
+}
+
+func TestTLSConfig_BuildTLSConfigClientOnly(t *testing.T) {
+	// Arrange: un cliente (ej: la base de datos) que solo verifica al servidor.
+	certFile, _ := writeTestCertificate(t, t.TempDir())
+	cfg := TLSConfig{Enabled: true, CAFile: certFile}
+
+	// Act
+	tlsCfg, err := cfg.BuildTLSConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, tlsCfg.Certificates)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsCfg.MinVersion, "Sin min_version se exige TLS 1.2")
+}
+
+func TestTLSConfig_BuildTLSConfigDisabled(t *testing.T) {
+	// Act
+	tlsCfg, err := TLSConfig{CertFile: "/no/existe.pem"}.BuildTLSConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+}
+
+func TestTLSConfig_BuildTLSConfigErrors(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	missing := filepath.Join(t.TempDir(), "no-existe.pem")
+
+	tests := map[string]struct {
+		cfg  TLSConfig
+		want string
+	}{
+		"sin archivos":          {cfg: TLSConfig{Enabled: true}, want: "enabled es true"},
+		"solo certificado":      {cfg: TLSConfig{Enabled: true, CertFile: certFile}, want: "deben indicarse juntos"},
+		"certificado no existe": {cfg: TLSConfig{Enabled: true, CertFile: missing, KeyFile: keyFile}, want: "cert_file"},
+		"ca no existe":          {cfg: TLSConfig{Enabled: true, CAFile: missing}, want: "ca_file"},
+		"versión inválida":      {cfg: TLSConfig{Enabled: true, CAFile: certFile, MinVersion: "1.4"}, want: "min_version"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := tt.cfg.BuildTLSConfig()
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidate_TLSMinVersion(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.HTTP.TLS.MinVersion = "1.4"
+	cfg.DB.TLS.MinVersion = "1.3"
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	assert.Equal(t, []string{"http.tls.min_version"}, fieldErrorPaths(err))
+}
@@ -0,0 +1,340 @@
+// validate.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError describe una violación de validación en un campo concreto.
+// Path es la ruta del campo en notación de puntos, tal como aparece en el YAML.
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+// Error implementa la interfaz error con el formato "ruta: mensaje".
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Validate comprueba la configuración (los tags de cada campo, las reglas propias
+// de cada sección y las reglas entre secciones) y devuelve un único error que agrupa
+// (con errors.Join) todas las violaciones encontradas, cada una como *FieldError
+// o, si implica a varias secciones, como *CrossFieldError.
+// Devuelve nil si la configuración es válida.
+//
+// Tags admitidos en los campos:
+//   - required:"true": el campo no puede quedar vacío (ej: application.name, database.host).
+//   - pattern:"RE": un campo string, si no está vacío, debe cumplir la expresión regular RE
+//     (ej: pattern:"^[a-z0-9-]+$"). Cada patrón se compila una sola vez.
+//   - min:"N" / max:"N": límites inclusivos para campos numéricos (enteros y flotantes).
+//   - file:"exists": la ruta de un campo string, si no está vacía, debe existir y ser legible.
+//   - maxdur:"D": un campo time.Duration no puede superar D (ej: maxdur:"24h").
+//   - minlen:"N": un campo string, si no está vacío, debe tener al menos N caracteres.
+//     El mensaje de error nunca incluye el valor, ya que suele tratarse de un secreto.
+//   - requiredif:"Campo=valor": el campo es obligatorio (no puede quedar vacío) cuando el
+//     campo hermano Campo, indicado por su nombre en Go, vale valor (ej: requiredif:"Enabled=true").
+//   - fileif:"Campo=valor": junto a file:"exists", el archivo solo se comprueba cuando el
+//     campo hermano Campo vale valor (ej: las rutas TLS, con fileif:"Enabled=true").
+func (c *Config) Validate() error {
+	errs := validateTags(reflect.ValueOf(c).Elem())
+	errs = append(errs, checkConditionalTags(reflect.ValueOf(c).Elem(), "")...)
+	errs = append(errs, c.DB.validate()...)
+	errs = append(errs, c.Audit.validate()...)
+	errs = append(errs, c.RateLimit.validate()...)
+	errs = append(errs, c.Tenancy.validate()...)
+	errs = append(errs, c.Migrations.validate()...)
+	errs = append(errs, c.Refresh.validate()...)
+	errs = append(errs, c.HTTP.validateCORS()...)
+	errs = append(errs, c.Proxy.validate()...)
+	errs = append(errs, c.Health.validate()...)
+	errs = append(errs, c.Kafka.validate()...)
+	errs = append(errs, validateCrossRules(c)...)
+	return errors.Join(errs...)
+}
+
+// runValidators ejecuta Validate() y a continuación cada validador personalizado,
+// y agrupa todos los errores en uno solo (nil si todo es válido).
+func runValidators(cfg *Config, validators []func(*Config) error) error {
+	errs := []error{cfg.Validate()}
+	for _, validator := range validators {
+		errs = append(errs, validator(cfg))
+	}
+	return errors.Join(errs...)
+}
+
+// validateTags aplica las reglas declaradas en los tags a cada campo del struct v.
+func validateTags(v reflect.Value) []error {
+	var errs []error
+	walkFields(v, "", func(path string, field reflect.StructField, value reflect.Value) {
+		if err := checkRequired(path, field, value); err != nil {
+			errs = append(errs, err)
+		}
+		errs = append(errs, checkBounds(path, field, value)...)
+		if _, conditional := field.Tag.Lookup("fileif"); !conditional {
+			if err := checkFile(path, field, value); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := checkMinLen(path, field, value); err != nil {
+			errs = append(errs, err)
+		}
+		if err := checkPattern(path, field, value); err != nil {
+			errs = append(errs, err)
+		}
+		if err := checkMaxDuration(path, field, value); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errs
+}
+
+// checkRequired valida el tag `required:"true"`: el campo no puede tener su valor cero.
+func checkRequired(path string, field reflect.StructField, value reflect.Value) error {
+	if field.Tag.Get("required") != "true" || !value.IsZero() {
+		return nil
+	}
+	return &FieldError{Path: path, Message: "es obligatorio"}
+}
+
+// checkBounds valida los tags `min` y `max` de un campo numérico.
+func checkBounds(path string, field reflect.StructField, value reflect.Value) []error {
+	n, ok := numericValue(value)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	if tag, ok := field.Tag.Lookup("min"); ok {
+		bound, err := strconv.ParseFloat(tag, 64)
+		if err != nil {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("tag min %q no es un número", tag)})
+		} else if n < bound {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("debe ser >= %s (valor: %v)", tag, value.Interface())})
+		}
+	}
+	if tag, ok := field.Tag.Lookup("max"); ok {
+		bound, err := strconv.ParseFloat(tag, 64)
+		if err != nil {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("tag max %q no es un número", tag)})
+		} else if n > bound {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("debe ser <= %s (valor: %v)", tag, value.Interface())})
+		}
+	}
+	return errs
+}
+
+// numericValue convierte a float64 el valor de un campo entero o flotante.
+func numericValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// checkMaxDuration valida el tag `maxdur` de un campo time.Duration.
+func checkMaxDuration(path string, field reflect.StructField, value reflect.Value) error {
+	tag, ok := field.Tag.Lookup("maxdur")
+	if !ok || value.Type() != reflect.TypeOf(time.Duration(0)) {
+		return nil
+	}
+	ceiling, err := time.ParseDuration(tag)
+	if err != nil {
+		return &FieldError{Path: path, Message: fmt.Sprintf("tag maxdur %q no es una duración", tag)}
+	}
+	if d := time.Duration(value.Int()); d > ceiling {
+		return &FieldError{Path: path, Message: fmt.Sprintf("no puede superar %s (valor: %s)", ceiling, d)}
+	}
+	return nil
+}
+
+// checkMinLen valida el tag `minlen` de un campo string no vacío sin revelar su valor.
+func checkMinLen(path string, field reflect.StructField, value reflect.Value) error {
+	tag, ok := field.Tag.Lookup("minlen")
+	if !ok || value.Kind() != reflect.String || value.String() == "" {
+		return nil
+	}
+	minLen, err := strconv.Atoi(tag)
+	if err != nil {
+		return &FieldError{Path: path, Message: fmt.Sprintf("tag minlen %q no es un entero", tag)}
+	}
+	if n := utf8.RuneCountInString(value.String()); n < minLen {
+		return &FieldError{Path: path, Message: fmt.Sprintf("debe tener al menos %d caracteres (tiene %d)", minLen, n)}
+	}
+	return nil
+}
+
+// checkConditionalTags valida los tags `requiredif` y `fileif` de los campos del struct
+// v y de sus secciones anidadas. No usa walkFields porque necesita acceder a los
+// campos hermanos.
+func checkConditionalTags(v reflect.Value, prefix string) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := fieldKey(field)
+		if !field.IsExported() || key == "-" {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if isSection(field.Type) {
+			errs = append(errs, checkConditionalTags(v.Field(i), path)...)
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("requiredif"); ok {
+			sibling, expected, active, valid := siblingCondition(v, tag)
+			switch {
+			case !valid:
+				errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("tag requiredif %q no es válido", tag)})
+			case active && v.Field(i).IsZero():
+				errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("es obligatorio cuando %s es %s", fieldKey(sibling), expected)})
+			}
+		}
+		if tag, ok := field.Tag.Lookup("fileif"); ok {
+			_, _, active, valid := siblingCondition(v, tag)
+			if !valid {
+				errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("tag fileif %q no es válido", tag)})
+			} else if active {
+				if err := checkFile(path, field, v.Field(i)); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// siblingCondition interpreta una condición "Campo=valor" sobre el struct v: devuelve
+// el campo hermano, el valor esperado, si la condición se cumple y si es válida.
+func siblingCondition(v reflect.Value, tag string) (sibling reflect.StructField, expected string, active, valid bool) {
+	name, expected, found := strings.Cut(tag, "=")
+	sibling, exists := v.Type().FieldByName(name)
+	if !found || !exists {
+		return sibling, expected, false, false
+	}
+	return sibling, expected, fmt.Sprint(v.FieldByIndex(sibling.Index).Interface()) == expected, true
+}
+
+// patterns guarda en caché las expresiones regulares de los tags `pattern`.
+var patterns sync.Map // string -> *regexp.Regexp
+
+// checkPattern valida el tag `pattern` de un campo string no vacío. El mensaje indica
+// el patrón pero no el valor, por si el campo es un secreto.
+func checkPattern(path string, field reflect.StructField, value reflect.Value) error {
+	tag, ok := field.Tag.Lookup("pattern")
+	if !ok || value.Kind() != reflect.String || value.String() == "" {
+		return nil
+	}
+	cached, ok := patterns.Load(tag)
+	if !ok {
+		re, err := regexp.Compile(tag)
+		if err != nil {
+			return &FieldError{Path: path, Message: fmt.Sprintf("tag pattern %q no es una expresión regular válida", tag)}
+		}
+		cached, _ = patterns.LoadOrStore(tag, re)
+	}
+	if !cached.(*regexp.Regexp).MatchString(value.String()) {
+		return &FieldError{Path: path, Message: fmt.Sprintf("no cumple el patrón %q", tag)}
+	}
+	return nil
+}
+
+// checkFile valida el tag `file:"exists"`: si el campo tiene una ruta, el archivo
+// debe existir y poder abrirse para lectura. Un campo vacío no se comprueba.
+func checkFile(path string, field reflect.StructField, value reflect.Value) error {
+	if field.Tag.Get("file") != "exists" || value.Kind() != reflect.String || value.String() == "" {
+		return nil
+	}
+	f, err := os.Open(value.String())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &FieldError{Path: path, Message: fmt.Sprintf("el archivo %q no existe", value.String())}
+		}
+		return &FieldError{Path: path, Message: fmt.Sprintf("el archivo %q no es legible: %v", value.String(), err)}
+	}
+	return f.Close()
+}
+
+// validate comprueba que ni el mínimo de conexiones del pool ni las conexiones de
+// precalentamiento superen el máximo. Un MaxConns a 0 deja el máximo al criterio del
+// driver y no se compara.
+func (d DBConfig) validate() []error {
+	if d.MaxConns <= 0 {
+		return nil
+	}
+	var errs []error
+	if d.MinConns > d.MaxConns {
+		errs = append(errs, &FieldError{Path: "database.min_connections", Message: fmt.Sprintf("no puede superar max_connections (%d > %d)", d.MinConns, d.MaxConns)})
+	}
+	if d.WarmupConns > d.MaxConns {
+		errs = append(errs, &FieldError{Path: "database.warmup_connections", Message: fmt.Sprintf("no puede superar max_connections (%d > %d)", d.WarmupConns, d.MaxConns)})
+	}
+	return errs
+}
+
+// validate comprueba que el destino de auditoría sea conocido y que, si es un
+// archivo, se haya indicado su ruta. Solo aplica cuando la auditoría está activa.
+func (a AuditConfig) validate() []error {
+	if !a.Enabled {
+		return nil
+	}
+	switch a.Destination {
+	case "", AuditDestinationStdout, AuditDestinationSyslog:
+		return nil
+	case AuditDestinationFile:
+		if a.FilePath == "" {
+			return []error{&FieldError{Path: "audit.file_path", Message: "es obligatorio cuando destination es \"file\""}}
+		}
+		return nil
+	default:
+		return []error{&FieldError{Path: "audit.destination", Message: fmt.Sprintf("%q no es válido (usa stdout, file o syslog)", a.Destination)}}
+	}
+}
+
+// validate comprueba el modo y la estrategia de aislamiento contra los valores
+// conocidos, y que el modo "single" indique el tenant por defecto.
+// Si Mode está vacío, la sección se considera no configurada.
+func (t TenancyConfig) validate() []error {
+	var errs []error
+	switch t.Mode {
+	case "":
+		return nil
+	case TenancyModeSingle:
+		if t.DefaultTenant == "" {
+			errs = append(errs, &FieldError{Path: "tenancy.default_tenant", Message: "es obligatorio cuando mode es \"single\""})
+		}
+	case TenancyModeMulti:
+		if t.IsolationStrategy == "" {
+			errs = append(errs, &FieldError{Path: "tenancy.isolation_strategy", Message: "es obligatorio cuando mode es \"multi\""})
+		}
+	default:
+		errs = append(errs, &FieldError{Path: "tenancy.mode", Message: fmt.Sprintf("%q no es válido (usa single o multi)", t.Mode)})
+	}
+
+	switch t.IsolationStrategy {
+	case "", TenantIsolationSchema, TenantIsolationDatabase, TenantIsolationRow:
+	default:
+		errs = append(errs, &FieldError{Path: "tenancy.isolation_strategy", Message: fmt.Sprintf("%q no es válido (usa schema, database o row)", t.IsolationStrategy)})
+	}
+	return errs
+}
@@ -0,0 +1,389 @@
+// validate_test.go
+package configloader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig devuelve una configuración mínima que pasa Validate().
+func validConfig() *Config {
+	return &Config{
+		App:  AppConfig{Name: "filingo", Port: 8080},
+		DB:   DBConfig{Host: "localhost", Port: 5432, MaxConns: 10, MinConns: 2},
+		HTTP: HTTPConfig{Port: 8080},
+	}
+}
+
+// fieldErrorPaths extrae las rutas de todos los *FieldError contenidos en err.
+func fieldErrorPaths(err error) []string {
+	var paths []string
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			paths = append(paths, fieldErrorPaths(e)...)
+		}
+		return paths
+	}
+	if fe, ok := err.(*FieldError); ok {
+		return append(paths, fe.Path)
+	}
+	if inner := errors.Unwrap(err); inner != nil {
+		return fieldErrorPaths(inner)
+	}
+	return paths
+}
+
+func TestValidate_InRange(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestValidate_UnderMin(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB.MaxConns = -1
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"database.max_connections"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), ">= 0")
+}
+
+func TestValidate_OverMax(t *testing.T) {
+	cfg := validConfig()
+	cfg.HTTP.Port = 70000
+	cfg.App.Port = 65536
+
+	err := cfg.Validate()
+
+	// Todas las violaciones se reportan juntas.
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"http.port", "application.port"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), "<= 65535")
+}
+
+func TestValidateTags_FloatBounds(t *testing.T) {
+	type sampling struct {
+		Ratio float64 `mapstructure:"ratio" min:"0" max:"1"`
+	}
+	type section struct {
+		Sampling sampling `mapstructure:"sampling"`
+	}
+
+	assert.Empty(t, validateTags(reflect.ValueOf(section{Sampling: sampling{Ratio: 0.5}})))
+
+	errs := validateTags(reflect.ValueOf(section{Sampling: sampling{Ratio: 1.5}}))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "sampling.ratio: debe ser <= 1 (valor: 1.5)", errs[0].Error())
+}
+
+func TestValidate_FileExists(t *testing.T) {
+	// Arrange: un certificado real y otro que no existe.
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cfg := validConfig()
+	cfg.HTTP.TLS = TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile}
+
+	// Assert: los archivos existentes pasan la validación.
+	require.NoError(t, cfg.Validate())
+
+	// Act: apuntamos la clave a una ruta inexistente.
+	cfg.HTTP.TLS.KeyFile = filepath.Join(t.TempDir(), "no-existe.key")
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, []string{"http.tls.key_file"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), "no existe")
+
+	// Con TLS desactivado los archivos no se comprueban.
+	cfg.HTTP.TLS.Enabled = false
+	cfg.DB.TLS = TLSConfig{CAFile: filepath.Join(t.TempDir(), "no-existe.crt")}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_Audit(t *testing.T) {
+	cfg := validConfig()
+
+	// Un destino de archivo sin ruta es un error.
+	cfg.Audit = AuditConfig{Enabled: true, Destination: AuditDestinationFile}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"audit.file_path"}, fieldErrorPaths(err))
+
+	// Con la ruta indicada es válido.
+	cfg.Audit.FilePath = "/var/log/filingo/audit.log"
+	assert.NoError(t, cfg.Validate())
+
+	// Un destino desconocido es un error.
+	cfg.Audit.Destination = "kafka"
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"audit.destination"}, fieldErrorPaths(err))
+
+	// Si la auditoría está desactivada no se valida nada.
+	cfg.Audit.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_CustomValidators(t *testing.T) {
+	// Arrange: una regla propia que relaciona dos campos.
+	redisPassword := func(cfg *Config) error {
+		if cfg.Redis.Address != "" && cfg.Redis.Password == "" {
+			return &FieldError{Path: "redis.password", Message: "es obligatorio si redis.address está configurado"}
+		}
+		return nil
+	}
+	yamlContent := `
+application:
+  name: "filingo"
+  port: 8080
+http:
+  port: 8080
+database:
+  host: "localhost"
+  port: 5432
+  max_connections: -5
+redis:
+  address: "localhost:6379"
+`
+	tempDir := writeTempConfig(t, "custom.yaml", yamlContent)
+
+	// Act
+	_, err := load(Options{
+		ConfigName:       "custom",
+		ConfigType:       "yaml",
+		ConfigPaths:      []string{tempDir},
+		CustomValidators: []func(*Config) error{redisPassword},
+	})
+
+	// Assert: se reportan tanto el error propio como el de la validación incorporada.
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"redis.password", "database.max_connections"}, fieldErrorPaths(err))
+}
+
+func TestValidate_RequiredFieldsAndPorts(t *testing.T) {
+	// Arrange: una configuración sin nombre, sin host de base de datos y sin puertos.
+	cfg := validConfig()
+	cfg.App.Name = ""
+	cfg.DB.Host = ""
+	cfg.HTTP.Port = 0
+	cfg.DB.Port = 70000
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"application.name", "database.host", "http.port", "database.port"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), "application.name: es obligatorio")
+}
+
+func TestValidate_Pattern(t *testing.T) {
+	tests := map[string]bool{
+		"filingo":       true,
+		"filingo-api-2": true,
+		"Filingo":       false,
+		"filingo_api":   false,
+		"filingo api":   false,
+	}
+	for name, valid := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			cfg.App.Name = name
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			if valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, []string{"application.name"}, fieldErrorPaths(err))
+			assert.Contains(t, err.Error(), `^[a-z0-9-]+$`)
+		})
+	}
+}
+
+func TestCheckPattern_InvalidRegexp(t *testing.T) {
+	field := reflect.StructField{Name: "Code", Type: reflect.TypeOf(""), Tag: `pattern:"[a-"`}
+	err := checkPattern("app.code", field, reflect.ValueOf("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no es una expresión regular válida")
+}
+
+func TestValidate_MinConnsAboveMaxConns(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB.MaxConns, cfg.DB.MinConns = 4, 8
+	assert.Equal(t, []string{"database.min_connections"}, fieldErrorPaths(cfg.Validate()))
+
+	// Sin máximo configurado no se compara.
+	cfg.DB.MaxConns = 0
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_WarmupConnsAboveMaxConns(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB.WarmupConns = cfg.DB.MaxConns + 1
+	assert.Equal(t, []string{"database.warmup_connections"}, fieldErrorPaths(cfg.Validate()))
+
+	cfg.DB.WarmupConns = cfg.DB.MaxConns
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_ValidateOnLoad(t *testing.T) {
+	// Arrange: un archivo que se decodifica sin problemas pero no es válido.
+	tempDir := writeTempConfig(t, "invalid.yaml", "application:\n  name: \"filingo\"\n")
+	opts := Options{ConfigName: "invalid", ConfigType: "yaml", ConfigPaths: []string{tempDir}}
+
+	// Act
+	_, errDefault := load(opts)
+	opts.ValidateOnLoad = true
+	_, err := load(opts)
+
+	// Assert
+	require.NoError(t, errDefault, "Sin la opción no se valida")
+	require.Error(t, err)
+	assert.Contains(t, fieldErrorPaths(err), "database.host")
+}
+
+func TestValidate_MinLen(t *testing.T) {
+	cfg := validConfig()
+
+	// Un secreto corto es un error y el mensaje no revela el valor.
+	cfg.OAuth2.SessionSecret = "secreto-corto"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"google_oauth2.session_secret"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), "al menos 32 caracteres")
+	assert.NotContains(t, err.Error(), "secreto-corto")
+
+	// Un secreto suficientemente largo es válido.
+	cfg.OAuth2.SessionSecret = "un-secreto-muy-largo-y-dificil-de-adivinar"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_Tenancy(t *testing.T) {
+	cfg := validConfig()
+
+	// Modo single sin tenant por defecto.
+	cfg.Tenancy = TenancyConfig{Mode: TenancyModeSingle}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"tenancy.default_tenant"}, fieldErrorPaths(err))
+
+	// Modo single completo.
+	cfg.Tenancy.DefaultTenant = "filingo"
+	assert.NoError(t, cfg.Validate())
+
+	// Modo y estrategia desconocidos.
+	cfg.Tenancy = TenancyConfig{Mode: "shared", IsolationStrategy: "table"}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"tenancy.mode", "tenancy.isolation_strategy"}, fieldErrorPaths(err))
+
+	// Modo multi con una estrategia válida.
+	cfg.Tenancy = TenancyConfig{Mode: TenancyModeMulti, IsolationStrategy: TenantIsolationRow}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_MaxDuration(t *testing.T) {
+	cfg := validConfig()
+
+	// Dentro del límite.
+	cfg.Token.Duration = 24 * time.Hour
+	assert.NoError(t, cfg.Validate())
+
+	// Por encima del límite (un año).
+	cfg.Token.Duration = 8760 * time.Hour
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"tokens.duration"}, fieldErrorPaths(err))
+	assert.Contains(t, err.Error(), "no puede superar 24h0m0s")
+}
+
+func TestCheckConditionalTags_RequiredIf(t *testing.T) {
+	type listener struct {
+		Enabled  bool   `mapstructure:"enabled"`
+		CertFile string `mapstructure:"cert_file" requiredif:"Enabled=true"`
+		Mode     string `mapstructure:"mode"`
+		CAFile   string `mapstructure:"ca_file" requiredif:"Mode=verify"`
+	}
+	type section struct {
+		Listener listener `mapstructure:"listener"`
+	}
+	tests := map[string]struct {
+		listener  listener
+		wantPaths []string
+	}{
+		"condición inactiva":           {listener{Enabled: false, Mode: "none"}, nil},
+		"condición activa y rellenado": {listener{Enabled: true, CertFile: "cert.pem"}, nil},
+		"condición activa y vacío":     {listener{Enabled: true}, []string{"listener.cert_file"}},
+		"condición sobre un string":    {listener{Mode: "verify"}, []string{"listener.ca_file"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			errs := checkConditionalTags(reflect.ValueOf(section{Listener: tc.listener}), "")
+
+			// Assert
+			assert.Equal(t, tc.wantPaths, fieldErrorPaths(errors.Join(errs...)))
+		})
+	}
+}
+
+func TestValidateTags_FileExists(t *testing.T) {
+	// Arrange: un struct propio con el tag file sin condición.
+	type section struct {
+		Template string `mapstructure:"template" file:"exists"`
+	}
+	existing := filepath.Join(t.TempDir(), "plantilla.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("hola"), 0o644))
+	missing := filepath.Join(t.TempDir(), "no-existe.txt")
+
+	// Act
+	okErrs := validateTags(reflect.ValueOf(section{Template: existing}))
+	emptyErrs := validateTags(reflect.ValueOf(section{}))
+	missingErrs := validateTags(reflect.ValueOf(section{Template: missing}))
+
+	// Assert
+	assert.Empty(t, okErrs)
+	assert.Empty(t, emptyErrs, "Un campo vacío no se comprueba")
+	require.Len(t, missingErrs, 1)
+	assert.Equal(t, "template", fieldErrorPaths(missingErrs[0])[0])
+	assert.Contains(t, missingErrs[0].Error(), "no existe")
+}
+
+func TestCheckConditionalTags_FileIf(t *testing.T) {
+	type listener struct {
+		Enabled  bool   `mapstructure:"enabled"`
+		CertFile string `mapstructure:"cert_file" file:"exists" fileif:"Enabled=true"`
+		KeyFile  string `mapstructure:"key_file" file:"exists" fileif:"Activo=true"`
+	}
+	missing := filepath.Join(t.TempDir(), "no-existe.pem")
+	tests := map[string]struct {
+		listener  listener
+		wantPaths []string
+	}{
+		"condición inactiva":  {listener{CertFile: missing}, []string{"key_file"}},
+		"condición activa":    {listener{Enabled: true, CertFile: missing}, []string{"cert_file", "key_file"}},
+		"sin ruta no se mira": {listener{Enabled: true}, []string{"key_file"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			errs := checkConditionalTags(reflect.ValueOf(tc.listener), "")
+
+			// Assert: el tag de key_file no es válido (no hay campo Activo).
+			assert.Equal(t, tc.wantPaths, fieldErrorPaths(errors.Join(errs...)))
+			assert.Empty(t, validateTags(reflect.ValueOf(tc.listener)), "validateTags no comprueba los campos con fileif")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// valuedirs.go
+
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// mergeValueDirs fusiona en v los valores de cada directorio de dirs, donde cada
+// archivo representa una clave: el nombre es la clave con puntos (ej: "database.host")
+// y el contenido, sin los saltos de línea finales, es su valor. Es el patrón de montaje
+// de Kubernetes (downward API / projected volumes).
+//
+// Se ignoran los subdirectorios y las entradas ocultas (como "..data" de Kubernetes).
+// Un directorio inexistente no es un error, igual que un archivo de configuración ausente.
+func mergeValueDirs(v *viper.Viper, dirs []string) error {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("error al leer el directorio de valores %q: %w", dir, err)
+		}
+
+		values := map[string]any{}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			// os.Stat sigue los enlaces simbólicos que usa Kubernetes para cada clave.
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("error al leer el valor %q: %w", path, err)
+			}
+			if !info.Mode().IsRegular() {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("error al leer el valor %q: %w", path, err)
+			}
+			setNested(values, entry.Name(), strings.TrimRight(string(content), "\r\n"))
+		}
+
+		if err := v.MergeConfigMap(values); err != nil {
+			return fmt.Errorf("error al fusionar el directorio de valores %q: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// setNested asigna value en m bajo la clave con puntos key, creando los mapas
+// intermedios necesarios ("a.b.c" -> m["a"]["b"]["c"]).
+func setNested(m map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[part] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = value
+}
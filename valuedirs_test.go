@@ -0,0 +1,44 @@
+// valuedirs_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ValueDirs(t *testing.T) {
+	// Arrange: un archivo por clave, como en un volumen proyectado de Kubernetes.
+	configDir := writeTempConfig(t, "base.yaml", "database:\n  host: \"db-file\"\n  name: \"filingo\"\n")
+	valuesDir := t.TempDir()
+	files := map[string]string{
+		"database.host":            "db-k8s\n",
+		"database.max_connections": "25\n",
+		"tokens.duration":          "2h",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(valuesDir, name), []byte(content), 0644))
+	}
+	// Las entradas ocultas y los subdirectorios se ignoran.
+	require.NoError(t, os.Mkdir(filepath.Join(valuesDir, "..data"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(valuesDir, ".hidden"), []byte("x"), 0644))
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:  "base",
+		ConfigType:  "yaml",
+		ConfigPaths: []string{configDir},
+		ValueDirs:   []string{valuesDir, filepath.Join(valuesDir, "no-existe")},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db-k8s", cfg.DB.Host, "El valor del archivo por clave debería sobrescribir al YAML")
+	assert.Equal(t, "filingo", cfg.DB.Name)
+	assert.Equal(t, int32(25), cfg.DB.MaxConns)
+	assert.Equal(t, 2*time.Hour, cfg.Token.Duration)
+}
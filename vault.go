@@ -0,0 +1,82 @@
+// vault.go
+
+package configloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// VaultProvider lee secretos de HashiCorp Vault por su API HTTP. Resuelve referencias
+// "ruta#campo" (ej: "vault:secret/data/db#password"), donde ruta es la del secreto
+// tal como aparece en la API y campo la clave dentro de él. Admite los motores KV
+// versión 1 y 2. Se registra en Options.SecretProviders con el esquema "vault".
+type VaultProvider struct {
+	Address   string       // ej: "https://vault.local:8200"; si está vacío, VAULT_ADDR
+	Token     string       // Si está vacío, VAULT_TOKEN
+	Namespace string       // Opcional (Vault Enterprise); si está vacío, VAULT_NAMESPACE
+	Client    *http.Client // Si es nil, http.DefaultClient
+}
+
+// Secret devuelve el campo indicado en ref ("ruta#campo") del secreto de Vault.
+func (p *VaultProvider) Secret(ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("la referencia %q no tiene el formato ruta#campo", ref)
+	}
+	address := firstNonEmpty(p.Address, os.Getenv("VAULT_ADDR"))
+	if address == "" {
+		return "", fmt.Errorf("vault: falta la dirección del servidor (VaultProvider.Address o VAULT_ADDR)")
+	}
+	endpoint, err := url.JoinPath(address, "v1", path)
+	if err != nil {
+		return "", fmt.Errorf("vault: dirección no válida %q: %w", address, err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", firstNonEmpty(p.Token, os.Getenv("VAULT_TOKEN")))
+	if namespace := firstNonEmpty(p.Namespace, os.Getenv("VAULT_NAMESPACE")); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%w en vault: %s", ErrSecretNotFound, path)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault: respuesta %s al leer %s", resp.Status, path)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault: respuesta no válida al leer %s: %w", path, err)
+	}
+	data := body.Data
+	// En KV versión 2 los valores van anidados en data.data, junto a data.metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, v2 := data["metadata"]; v2 {
+			data = nested
+		}
+	}
+	value, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("%w en vault: %s#%s", ErrSecretNotFound, path, field)
+	}
+	return value, nil
+}
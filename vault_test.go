@@ -0,0 +1,85 @@
+// vault_test.go
+package configloader
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault simula la API de Vault con un secreto KV v2 y otro KV v1.
+func fakeVault(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "pg-secreto"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/redis":
+			_, _ = w.Write([]byte(`{"data": {"password": "redis-secreto"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoad_VaultSecrets(t *testing.T) {
+	// Arrange
+	srv := fakeVault(t)
+	yamlContent := `
+database:
+  host: "localhost"
+  password: "vault:secret/data/db#password"
+redis:
+  address: "localhost:6379"
+  password: "vault://kv/redis#password"
+`
+	tempDir := writeTempConfig(t, "vault.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{
+		ConfigName:      "vault",
+		ConfigType:      "yaml",
+		ConfigPaths:     []string{tempDir},
+		SecretProviders: map[string]SecretProvider{"vault": &VaultProvider{Address: srv.URL, Token: "root-token"}},
+	})
+
+	// Assert: se resuelven las referencias y el resto de valores no cambia.
+	require.NoError(t, err)
+	assert.Equal(t, "pg-secreto", cfg.DB.Password)
+	assert.Equal(t, "redis-secreto", cfg.Redis.Password)
+	assert.Equal(t, "localhost:6379", cfg.Redis.Address)
+}
+
+func TestVaultProvider_Errors(t *testing.T) {
+	srv := fakeVault(t)
+	tests := map[string]struct {
+		provider *VaultProvider
+		ref      string
+		notFound bool
+	}{
+		"sin campo":         {&VaultProvider{Address: srv.URL, Token: "root-token"}, "secret/data/db", false},
+		"ruta inexistente":  {&VaultProvider{Address: srv.URL, Token: "root-token"}, "secret/data/otro#password", true},
+		"campo inexistente": {&VaultProvider{Address: srv.URL, Token: "root-token"}, "secret/data/db#user", true},
+		"token no válido":   {&VaultProvider{Address: srv.URL, Token: "otro"}, "secret/data/db#password", false},
+		"sin dirección":     {&VaultProvider{Token: "root-token"}, "secret/data/db#password", false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("VAULT_ADDR", "")
+
+			_, err := tc.provider.Secret(tc.ref)
+
+			require.Error(t, err)
+			assert.Equal(t, tc.notFound, errors.Is(err, ErrSecretNotFound))
+		})
+	}
+}
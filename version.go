@@ -0,0 +1,31 @@
+// version.go
+
+package configloader
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// semVerPattern reconoce una versión semántica 2.0.0 con prefijo "v" opcional,
+// sin ceros a la izquierda y con pre-release y metadatos de compilación opcionales.
+var semVerPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+// SemVer interpreta Version como versión semántica (ej: "1.2.3", "v1.2.3" o
+// "1.2.3-rc.1+build.5") y devuelve sus componentes numéricos. La pre-release y los
+// metadatos se validan pero no se devuelven. Devuelve un error si Version no es válida.
+func (a *AppConfig) SemVer() (major, minor, patch int, err error) {
+	m := semVerPattern.FindStringSubmatch(a.Version)
+	if m == nil {
+		return 0, 0, 0, fmt.Errorf("la versión %q no es una versión semántica válida (ej: 1.2.3)", a.Version)
+	}
+	parts := [3]int{}
+	for i, s := range m[1:4] {
+		if parts[i], err = strconv.Atoi(s); err != nil {
+			return 0, 0, 0, fmt.Errorf("la versión %q no es una versión semántica válida: %w", a.Version, err)
+		}
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,38 @@
+// version_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppConfig_SemVer(t *testing.T) {
+	tests := map[string][3]int{
+		"1.2.3":            {1, 2, 3},
+		"v1.2.3":           {1, 2, 3},
+		"10.0.1-rc.1+b.42": {10, 0, 1},
+	}
+	for version, want := range tests {
+		t.Run(version, func(t *testing.T) {
+			// Arrange
+			app := AppConfig{Version: version}
+
+			// Act
+			major, minor, patch, err := app.SemVer()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, want, [3]int{major, minor, patch})
+		})
+	}
+}
+
+func TestAppConfig_SemVerInvalid(t *testing.T) {
+	for _, version := range []string{"", "1.2", "1.2.x", "01.2.3", "version-1"} {
+		app := AppConfig{Version: version}
+		_, _, _, err := app.SemVer()
+		assert.Error(t, err, "%q no debería ser una versión válida", version)
+	}
+}
@@ -0,0 +1,65 @@
+// view.go
+
+package configloader
+
+import "reflect"
+
+// ConfigView es una vista de solo lectura de Config. Cada getter devuelve una
+// copia profunda de la sección (incluidos sus slices y mapas), así que quien recibe un ConfigView no puede modificar la
+// configuración compartida. Los handlers deberían aceptar ConfigView en lugar de *Config.
+type ConfigView interface {
+	App() AppConfig
+	DB() DBConfig
+	HTTP() HTTPConfig
+	Redis() RedisConfig
+	OAuth2() OAuthConfig
+	Token() TokenConfig
+	Audit() AuditConfig
+	RateLimit() RateLimitConfig
+	Recovery() RecoveryConfig
+	Tenancy() TenancyConfig
+	Maintenance() MaintenanceConfig
+	Migrations() MigrationConfig
+	Refresh() RefreshConfig
+	Proxy() ProxyConfig
+	Health() HealthConfig
+	Kafka() KafkaConfig
+	Warnings() []string
+	SourceFile() string
+}
+
+// configView implementa ConfigView sobre un *Config.
+type configView struct {
+	cfg *Config
+}
+
+// View devuelve una vista de solo lectura que refleja el estado de c.
+func (c *Config) View() ConfigView {
+	return configView{cfg: c}
+}
+
+func (v configView) App() AppConfig                 { return sectionCopy(v.cfg.App) }
+func (v configView) DB() DBConfig                   { return sectionCopy(v.cfg.DB) }
+func (v configView) HTTP() HTTPConfig               { return sectionCopy(v.cfg.HTTP) }
+func (v configView) Redis() RedisConfig             { return sectionCopy(v.cfg.Redis) }
+func (v configView) OAuth2() OAuthConfig            { return sectionCopy(v.cfg.OAuth2) }
+func (v configView) Token() TokenConfig             { return sectionCopy(v.cfg.Token) }
+func (v configView) Audit() AuditConfig             { return sectionCopy(v.cfg.Audit) }
+func (v configView) RateLimit() RateLimitConfig     { return sectionCopy(v.cfg.RateLimit) }
+func (v configView) Recovery() RecoveryConfig       { return sectionCopy(v.cfg.Recovery) }
+func (v configView) Tenancy() TenancyConfig         { return sectionCopy(v.cfg.Tenancy) }
+func (v configView) Maintenance() MaintenanceConfig { return sectionCopy(v.cfg.Maintenance) }
+func (v configView) Migrations() MigrationConfig    { return sectionCopy(v.cfg.Migrations) }
+func (v configView) Refresh() RefreshConfig         { return sectionCopy(v.cfg.Refresh) }
+func (v configView) Proxy() ProxyConfig             { return sectionCopy(v.cfg.Proxy) }
+func (v configView) Health() HealthConfig {
+	return HealthConfig{Dependencies: v.cfg.HealthDependencies()}
+}
+func (v configView) Kafka() KafkaConfig { return sectionCopy(v.cfg.Kafka) }
+func (v configView) Warnings() []string { return v.cfg.Warnings() }
+func (v configView) SourceFile() string { return v.cfg.SourceFile() }
+
+// sectionCopy devuelve una copia profunda de section (ver deepCopy).
+func sectionCopy[T any](section T) T {
+	return deepCopy(reflect.ValueOf(section)).Interface().(T)
+}
@@ -0,0 +1,40 @@
+// view_test.go
+package configloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView_ReflectsConfig(t *testing.T) {
+	// Arrange
+	cfg := &Config{
+		App:   AppConfig{Name: "filingo"},
+		DB:    DBConfig{Host: "db-host", MaxConns: 10},
+		HTTP:  HTTPConfig{AllowedOrigins: []string{"https://filingo.io"}},
+		Redis: RedisConfig{Address: "localhost:6379"},
+		RateLimit: RateLimitConfig{
+			Routes: map[string]RouteLimit{"/login": {RequestsPerSecond: 1}},
+		},
+	}
+	view := cfg.View()
+
+	// Act: modificar la copia devuelta por la vista no afecta a la configuración.
+	db := view.DB()
+	db.Host = "modificado"
+	view.HTTP().AllowedOrigins[0] = "evil"
+	delete(view.RateLimit().Routes, "/login")
+
+	// Assert
+	assert.Equal(t, "filingo", view.App().Name)
+	assert.Equal(t, "db-host", view.DB().Host)
+	assert.Equal(t, "db-host", cfg.DB.Host)
+	assert.Equal(t, "localhost:6379", view.Redis().Address)
+	assert.Equal(t, []string{"https://filingo.io"}, cfg.HTTP.AllowedOrigins, "Los slices devueltos deberían ser copias")
+	assert.Contains(t, cfg.RateLimit.Routes, "/login", "Los mapas devueltos deberían ser copias")
+
+	// La vista refleja los cambios hechos sobre la configuración subyacente.
+	cfg.DB.MaxConns = 20
+	assert.Equal(t, int32(20), view.DB().MaxConns)
+}
@@ -0,0 +1,74 @@
+// warnings.go
+
+package configloader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Logger es la interfaz mínima que usa la carga para emitir avisos. Es compatible
+// con *slog.Logger, así que se puede pasar directamente el logger de la aplicación.
+type Logger interface {
+	Warn(msg string, fields ...any)
+}
+
+// deprecatedKeys contiene las claves marcadas como obsoletas y su mensaje.
+var (
+	deprecatedMu   sync.RWMutex
+	deprecatedKeys = map[string]string{}
+)
+
+// RegisterDeprecatedKey marca una clave (ruta con puntos, ej: "database.pool_size")
+// como obsoleta. Si una carga posterior encuentra la clave definida en cualquier
+// fuente, añade el mensaje indicado a Config.Warnings(). Es seguro llamarla desde
+// varias goroutines; volver a registrar una clave reemplaza su mensaje.
+func RegisterDeprecatedKey(key string, message string) {
+	deprecatedMu.Lock()
+	defer deprecatedMu.Unlock()
+	deprecatedKeys[strings.ToLower(key)] = message
+}
+
+// Warnings devuelve los avisos no fatales generados durante la carga
+// (por ejemplo, claves obsoletas). Devuelve una copia; nil si no hubo avisos.
+func (c *Config) Warnings() []string {
+	if len(c.warnings) == 0 {
+		return nil
+	}
+	return append([]string(nil), c.warnings...)
+}
+
+// deprecationWarnings devuelve un aviso por cada clave obsoleta definida en v,
+// ordenados por clave para que el resultado sea estable.
+func deprecationWarnings(v *viper.Viper) []string {
+	deprecatedMu.RLock()
+	defer deprecatedMu.RUnlock()
+
+	keys := make([]string, 0, len(deprecatedKeys))
+	for key := range deprecatedKeys {
+		if v.IsSet(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	warnings := make([]string, 0, len(keys))
+	for _, key := range keys {
+		warnings = append(warnings, fmt.Sprintf("la clave %q está obsoleta: %s", key, deprecatedKeys[key]))
+	}
+	return warnings
+}
+
+// sectionWarnings devuelve avisos sobre combinaciones de valores que no son
+// inválidas, pero sí desaconsejables.
+func sectionWarnings(cfg *Config) []string {
+	var warnings []string
+	if cfg.App.Environment == "production" && cfg.Recovery.IncludeStackTrace {
+		warnings = append(warnings, "recovery.include_stack_trace está activo en producción: las respuestas pueden exponer detalles internos")
+	}
+	return warnings
+}
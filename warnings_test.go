@@ -0,0 +1,110 @@
+// warnings_test.go
+package configloader
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_DeprecatedKeyWarning(t *testing.T) {
+	// Arrange
+	RegisterDeprecatedKey("redis.address", "usa redis.url en su lugar")
+	RegisterDeprecatedKey("database.pool_size", "no se usa desde la v2")
+	t.Cleanup(func() {
+		deprecatedMu.Lock()
+		delete(deprecatedKeys, "redis.address")
+		delete(deprecatedKeys, "database.pool_size")
+		deprecatedMu.Unlock()
+	})
+	tempDir := writeTempConfig(t, "deprecated.yaml", "redis:\n  address: \"localhost:6379\"\n")
+
+	// Act
+	cfg, err := load(Options{ConfigName: "deprecated", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert: solo avisa de la clave presente, con el mensaje registrado.
+	require.NoError(t, err)
+	require.Len(t, cfg.Warnings(), 1)
+	assert.Contains(t, cfg.Warnings()[0], "redis.address")
+	assert.Contains(t, cfg.Warnings()[0], "usa redis.url en su lugar")
+}
+
+func TestLoad_RecoveryDefaults(t *testing.T) {
+	// Arrange: sin sección recovery en el archivo.
+	tempDir := writeTempConfig(t, "norecovery.yaml", "application:\n  name: \"filingo\"\n")
+
+	// Act
+	cfg, err := load(Options{ConfigName: "norecovery", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cfg.Recovery.Enabled, "recovery.enabled debería ser true por defecto")
+	assert.False(t, cfg.Recovery.IncludeStackTrace, "recovery.include_stack_trace debería ser false por defecto")
+	assert.Empty(t, cfg.Warnings())
+}
+
+func TestLoad_StackTraceInProductionWarning(t *testing.T) {
+	// Arrange
+	yamlContent := `
+application:
+  environment: "production"
+recovery:
+  include_stack_trace: true
+`
+	tempDir := writeTempConfig(t, "prod.yaml", yamlContent)
+
+	// Act
+	cfg, err := load(Options{ConfigName: "prod", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert: es un aviso, no un error.
+	require.NoError(t, err)
+	require.Len(t, cfg.Warnings(), 1)
+	assert.Contains(t, cfg.Warnings()[0], "recovery.include_stack_trace")
+}
+
+// recordingLogger guarda los avisos recibidos. Es seguro para uso concurrente.
+type recordingLogger struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+func (l *recordingLogger) Warn(msg string, _ ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, msg)
+}
+
+// messages devuelve una copia de los avisos recibidos hasta el momento.
+func (l *recordingLogger) messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.warnings)
+}
+
+// *slog.Logger debe poder usarse como Options.Logger.
+var _ Logger = slog.Default()
+
+func TestLoad_LoggerReceivesWarnings(t *testing.T) {
+	// Arrange
+	RegisterDeprecatedKey("redis.address", "usa redis.url en su lugar")
+	t.Cleanup(func() {
+		deprecatedMu.Lock()
+		delete(deprecatedKeys, "redis.address")
+		deprecatedMu.Unlock()
+	})
+	tempDir := writeTempConfig(t, "logged.yaml", "redis:\n  address: \"localhost:6379\"\n")
+	logger := &recordingLogger{}
+
+	// Act
+	cfg, err := load(Options{ConfigName: "logged", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Logger: logger})
+
+	// Assert: el logger recibe lo mismo que se acumula en Warnings().
+	require.NoError(t, err)
+	require.Len(t, logger.warnings, 1)
+	assert.Contains(t, logger.warnings[0], "redis.address")
+	assert.Equal(t, cfg.Warnings(), logger.warnings)
+}
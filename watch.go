@@ -0,0 +1,167 @@
+// watch.go
+
+package configloader
+
+import (
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce es el tiempo sin eventos que se espera antes de recargar: un solo
+// guardado desde un editor suele producir varios eventos seguidos.
+const watchDebounce = 100 * time.Millisecond
+
+var (
+	// changeCallbacks son las funciones registradas con OnChange.
+	changeMu        sync.RWMutex
+	changeCallbacks []func(*Config)
+
+	// activeWatcher es el vigilante del archivo del singleton, protegido por mu.
+	activeWatcher *configWatcher
+)
+
+// OnChange registra fn para que se llame con la nueva configuración cada vez que
+// Options.Watch recarga el singleton tras un cambio del archivo. Las funciones se
+// llaman en orden de registro, fuera de cualquier lock y después de que Get() ya
+// devuelva la nueva configuración. Diff indica qué campos cambiaron.
+func OnChange(fn func(*Config)) {
+	changeMu.Lock()
+	defer changeMu.Unlock()
+	changeCallbacks = append(changeCallbacks, fn)
+}
+
+// configWatcher vigila el archivo de configuración y recarga el singleton.
+type configWatcher struct {
+	fsw  *fsnotify.Watcher
+	opts Options
+	file string
+	// target es el archivo real al que apunta file si es un enlace simbólico. Solo lo
+	// usa la goroutine de loop.
+	target string
+
+	// reloadMu serializa las recargas: cada temporizador lanza reload en su propia
+	// goroutine, y sin él una carga más lenta podría publicarse después de otra más
+	// reciente o los callbacks de dos recargas podrían ejecutarse a la vez.
+	reloadMu sync.Mutex
+}
+
+// startWatcher empieza a vigilar file. Se vigila el directorio que lo contiene, no el
+// archivo, porque muchos editores guardan escribiendo uno nuevo y renombrándolo, y
+// Kubernetes actualiza los ConfigMaps sustituyendo el enlace ..data del directorio
+// sin tocar el enlace del archivo.
+func startWatcher(opts Options, file string) (*configWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fsw.Add(filepath.Dir(file)); err != nil {
+		_ = fsw.Close()
+		return nil, err
+	}
+	w := &configWatcher{fsw: fsw, opts: opts, file: filepath.Clean(file)}
+	w.target, _ = filepath.EvalSymlinks(w.file)
+	go w.loop()
+	return w, nil
+}
+
+// loop agrupa los eventos del archivo y lanza una recarga cuando pasa delay() sin
+// eventos nuevos. Termina al cerrar el watcher.
+func (w *configWatcher) loop() {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			// retarget va primero para registrar el nuevo destino en cualquier caso.
+			if !w.retarget() && filepath.Clean(event.Name) != w.file {
+				continue
+			}
+			if timer == nil {
+				timer = time.AfterFunc(w.delay(), w.reload)
+			} else {
+				timer.Reset(w.delay())
+			}
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			w.warn("error al vigilar el archivo de configuración", err)
+		}
+	}
+}
+
+// retarget vuelve a resolver los enlaces simbólicos de file e indica si ahora apunta
+// a otro archivo, como ocurre cuando Kubernetes sustituye el enlace ..data.
+func (w *configWatcher) retarget() bool {
+	target, err := filepath.EvalSymlinks(w.file)
+	if err != nil || target == w.target {
+		return false
+	}
+	w.target = target
+	return true
+}
+
+// delay es el tiempo sin cambios que se espera antes de recargar: el periodo de gracia
+// de las opciones o, si no hay, watchDebounce.
+func (w *configWatcher) delay() time.Duration {
+	if w.opts.ReloadGracePeriod > 0 {
+		return w.opts.ReloadGracePeriod
+	}
+	return watchDebounce
+}
+
+// reload vuelve a cargar la configuración y, si es válida, la publica de una vez en
+// el singleton y avisa a los callbacks de OnChange. Si la carga falla se conserva la
+// configuración anterior. Las recargas se ejecutan de una en una.
+func (w *configWatcher) reload() {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	cfg, err := LoadConfig(w.opts)
+	if err != nil {
+		w.warn("no se pudo recargar la configuración; se conserva la anterior", err)
+		return
+	}
+
+	mu.Lock()
+	if activeWatcher != w {
+		// Reset o un Init posterior han sustituido a este vigilante.
+		mu.Unlock()
+		return
+	}
+	publish(cfg, w.opts)
+	mu.Unlock()
+
+	changeMu.RLock()
+	callbacks := slices.Clone(changeCallbacks)
+	changeMu.RUnlock()
+	for _, fn := range callbacks {
+		fn(cfg)
+	}
+}
+
+// warn envía un aviso al Logger de las opciones, si lo hay.
+func (w *configWatcher) warn(msg string, err error) {
+	if w.opts.Logger != nil {
+		w.opts.Logger.Warn(msg, "file", w.file, "error", err)
+	}
+}
+
+// close deja de vigilar el archivo.
+func (w *configWatcher) close() {
+	_ = w.fsw.Close()
+}
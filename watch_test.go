@@ -0,0 +1,169 @@
+// watch_test.go
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit_WatchReloadsOnChange(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	tempDir := writeTempConfig(t, "watched.yaml", "application:\n  name: \"antes\"\n")
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Watch: true}))
+	changed := make(chan *Config, 10)
+	OnChange(func(cfg *Config) { changed <- cfg })
+	previous := Get()
+
+	// Act
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "watched.yaml"), []byte("application:\n  name: \"después\"\n"), 0o644))
+
+	// Assert
+	select {
+	case cfg := <-changed:
+		assert.Equal(t, "después", cfg.App.Name)
+		assert.Same(t, cfg, Get(), "Get() debería devolver la nueva configuración")
+		assert.Equal(t, "antes", previous.App.Name, "La configuración anterior no debería modificarse")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no se recibió la recarga")
+	}
+}
+
+func TestInit_WatchDebouncesEvents(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	tempDir := writeTempConfig(t, "watched.yaml", "application:\n  name: \"v0\"\n")
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Watch: true}))
+	var reloads atomic.Int32
+	OnChange(func(*Config) { reloads.Add(1) })
+
+	// Act: varias escrituras seguidas, como las de un editor al guardar.
+	path := filepath.Join(tempDir, "watched.yaml")
+	for _, name := range []string{"v1", "v2", "v3"} {
+		require.NoError(t, os.WriteFile(path, []byte("application:\n  name: \""+name+"\"\n"), 0o644))
+	}
+
+	// Assert: una sola recarga, con el contenido final.
+	require.Eventually(t, func() bool { return reloads.Load() > 0 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(3 * watchDebounce)
+	assert.Equal(t, int32(1), reloads.Load())
+	assert.Equal(t, "v3", Get().App.Name)
+}
+
+func TestInit_WatchSerializesReloads(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	tempDir := writeTempConfig(t, "watched.yaml", "application:\n  name: \"v0\"\n")
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Watch: true}))
+	mu.RLock()
+	w := activeWatcher
+	mu.RUnlock()
+	var running, overlaps atomic.Int32
+	OnChange(func(*Config) {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "watched.yaml"), []byte("application:\n  name: \"v1\"\n"), 0o644))
+
+	// Act: varias recargas a la vez, como las de temporizadores que vencen juntos.
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.reload()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Zero(t, overlaps.Load(), "Los callbacks de dos recargas no deberían solaparse")
+	assert.Equal(t, "v1", Get().App.Name)
+}
+
+func TestInit_WatchKeepsConfigOnInvalidChange(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	tempDir := writeTempConfig(t, "watched.yaml", "application:\n  name: \"válida\"\n")
+	logger := &recordingLogger{}
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Watch: true, Logger: logger}))
+
+	// Act
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "watched.yaml"), []byte("application: [roto\n"), 0o644))
+
+	// Assert
+	require.Eventually(t, func() bool { return len(logger.messages()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "válida", Get().App.Name)
+}
+
+func TestInit_WatchReloadGracePeriod(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange
+	const grace = 300 * time.Millisecond
+	tempDir := writeTempConfig(t, "watched.yaml", "application:\n  name: \"v0\"\n")
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{tempDir}, Watch: true, ReloadGracePeriod: grace}))
+	applied := make(chan time.Time, 10)
+	OnChange(func(*Config) { applied <- time.Now() })
+
+	// Act: una ráfaga de escrituras separadas por menos que el periodo de gracia.
+	path := filepath.Join(tempDir, "watched.yaml")
+	var lastWrite time.Time
+	for _, name := range []string{"v1", "v2", "v3"} {
+		lastWrite = time.Now()
+		require.NoError(t, os.WriteFile(path, []byte("application:\n  name: \""+name+"\"\n"), 0o644))
+		time.Sleep(grace / 3)
+	}
+
+	// Assert: una única recarga, aplicada después del periodo de gracia.
+	select {
+	case at := <-applied:
+		assert.GreaterOrEqual(t, at.Sub(lastWrite), grace)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no se aplicó la recarga")
+	}
+	time.Sleep(2 * grace)
+	assert.Empty(t, applied, "La ráfaga debería producir una sola recarga")
+	assert.Equal(t, "v3", Get().App.Name)
+}
+
+func TestInit_WatchKubernetesConfigMapSwap(t *testing.T) {
+	t.Cleanup(Reset)
+
+	// Arrange: la estructura de un ConfigMap montado, con el archivo enlazado a
+	// ..data/watched.yaml y ..data enlazado a un directorio con marca de tiempo.
+	dir := t.TempDir()
+	writeVersion := func(version, name string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, version), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "watched.yaml"), []byte("application:\n  name: \""+name+"\"\n"), 0o644))
+	}
+	writeVersion("..2026_01_01_00_00_00.1", "antes")
+	require.NoError(t, os.Symlink("..2026_01_01_00_00_00.1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "watched.yaml"), filepath.Join(dir, "watched.yaml")))
+	require.NoError(t, Init(Options{ConfigName: "watched", ConfigType: "yaml", ConfigPaths: []string{dir}, Watch: true}))
+	require.Equal(t, "antes", Get().App.Name)
+
+	// Act: la actualización atómica de Kubernetes: nueva versión, enlace temporal
+	// renombrado sobre ..data y borrado de la versión anterior.
+	writeVersion("..2026_01_01_00_05_00.2", "después")
+	require.NoError(t, os.Symlink("..2026_01_01_00_05_00.2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..2026_01_01_00_00_00.1")))
+
+	// Assert
+	require.Eventually(t, func() bool { return Get().App.Name == "después" }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,110 @@
+// writeyaml.go
+
+package configloader
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretPlaceholder es el comentario que sustituye al valor de un secreto omitido en WriteYAML.
+const secretPlaceholder = "secreto: indica el valor, una clave *_file o una referencia a un gestor de secretos"
+
+// WriteYAML escribe la configuración en w como un archivo YAML válido para cargarlo
+// después: con las claves de los tags `mapstructure`, en el orden en que se declaran
+// los campos, y el tag `doc` de cada campo como comentario encima de su clave. Las
+// duraciones se escriben legibles (ej: "1h30m0s"). Las secciones de plugins registradas
+// se añaden al final, ordenadas por clave.
+//
+// Si includeSecrets es false, los campos `sensitive:"true"` se escriben vacíos con un
+// comentario que indica que hay que rellenarlos. Pensado para generar plantillas (ej:
+// `myapp config init`) a partir de los valores por defecto.
+func (c *Config) WriteYAML(w io.Writer, includeSecrets bool) error {
+	doc := structNode(reflect.ValueOf(c).Elem(), includeSecrets)
+	for _, key := range sortedKeys(c.sections) {
+		section := reflect.ValueOf(c.sections[key])
+		if section.Kind() == reflect.Pointer && !section.IsNil() && section.Elem().Kind() == reflect.Struct {
+			doc.Content = append(doc.Content, scalarNode(key), structNode(section.Elem(), includeSecrets))
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("error al escribir la configuración en YAML: %w", err)
+	}
+	return enc.Close()
+}
+
+// structNode convierte un struct de configuración en un mapa YAML que conserva el
+// orden de los campos.
+func structNode(v reflect.Value, includeSecrets bool) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || fieldKey(field) == "-" {
+			continue
+		}
+		key := scalarNode(fieldKey(field))
+		key.HeadComment = field.Tag.Get("doc")
+		value := valueNode(v.Field(i), includeSecrets)
+		if isSensitive(field) && !includeSecrets {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "", LineComment: secretPlaceholder}
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node
+}
+
+// valueNode convierte un valor de configuración en su nodo YAML.
+func valueNode(v reflect.Value, includeSecrets bool) *yaml.Node {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return scalarNode(time.Duration(v.Int()).String())
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return structNode(v, includeSecrets)
+	case reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			node.Content = append(node.Content, scalarNode(key), valueNode(values[key], includeSecrets))
+		}
+		return node
+	case reflect.Slice, reflect.Array:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for i := 0; i < v.Len(); i++ {
+			node.Content = append(node.Content, valueNode(v.Index(i), includeSecrets))
+		}
+		return node
+	default:
+		node := &yaml.Node{}
+		_ = node.Encode(v.Interface()) // Los escalares de Config siempre se pueden codificar.
+		return node
+	}
+}
+
+// scalarNode devuelve un nodo de texto.
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
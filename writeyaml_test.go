@@ -0,0 +1,71 @@
+// writeyaml_test.go
+package configloader
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WriteYAMLRoundTrip(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.DB.Password = "p@ss: #word"
+	cfg.DB.MaxConnLifeTime = 90 * time.Minute
+	cfg.HTTP.AllowedOrigins = []string{"https://a.com", "https://b.com"}
+	cfg.RateLimit.Routes = map[string]RouteLimit{"/api": {RequestsPerSecond: 2, Burst: 5}}
+	var buf bytes.Buffer
+
+	// Act
+	err := cfg.WriteYAML(&buf, true)
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "written.yaml"), buf.Bytes(), 0644))
+	loaded, errLoad := load(Options{ConfigName: "written", ConfigType: "yaml", ConfigPaths: []string{tempDir}})
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, errLoad, buf.String())
+	assert.Equal(t, cfg.DB, loaded.DB)
+	assert.Equal(t, cfg.HTTP.AllowedOrigins, loaded.HTTP.AllowedOrigins)
+	assert.Equal(t, cfg.RateLimit.Routes, loaded.RateLimit.Routes)
+	assert.Contains(t, buf.String(), "max_connection_life_time: 1h30m0s")
+}
+
+func TestConfig_WriteYAMLOrderAndComments(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := validConfig().WriteYAML(&buf, true)
+
+	// Assert
+	require.NoError(t, err)
+	out := buf.String()
+	assert.Less(t, strings.Index(out, "application:"), strings.Index(out, "database:"), "Las secciones siguen el orden del struct")
+	assert.Less(t, strings.Index(out, "  driver:"), strings.Index(out, "  host: localhost"))
+	assert.Contains(t, out, "# Puerto del servidor de base de datos\n  port: 5432")
+	assert.NotContains(t, out, "MaxConns", "Se usan las claves de mapstructure, no los nombres de Go")
+}
+
+func TestConfig_WriteYAMLRedactsSecrets(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.DB.Password = "db-secreto"
+	cfg.Kafka.SASL.Password = "kafka-secreto"
+	var buf bytes.Buffer
+
+	// Act
+	err := cfg.WriteYAML(&buf, false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "db-secreto")
+	assert.NotContains(t, buf.String(), "kafka-secreto")
+	assert.Contains(t, buf.String(), `password: "" # `+secretPlaceholder)
+}